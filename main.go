@@ -2,18 +2,35 @@ package main
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"net/http"
 	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
 	"time"
 
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/api"
 	"buyallmemes.com/blog-api/src/infrastructure/logging"
 	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"buyallmemes.com/blog-api/src/infrastructure/metrics"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/cache"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/git"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/gitea"
 	"buyallmemes.com/blog-api/src/infrastructure/repository/github"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/gitlab"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/multi"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/s3"
+	"buyallmemes.com/blog-api/src/infrastructure/secrets"
+	"buyallmemes.com/blog-api/src/infrastructure/seo"
 	blogUsecase "buyallmemes.com/blog-api/src/usecase/blog"
 	"github.com/aws/aws-lambda-go/events"
 	"github.com/aws/aws-lambda-go/lambda"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	awss3 "github.com/aws/aws-sdk-go-v2/service/s3"
 	"github.com/mfenderov/konfig"
 	"github.com/pkg/errors"
 )
@@ -21,6 +38,15 @@ import (
 // Global logger instance
 var logger *logging.Logger
 
+// Diagram renderer shared across invocations, so rendered diagrams stay cached while the Lambda is warm
+var diagramRenderer markdown.DiagramRenderer
+
+// Router shared across invocations, so the cached posts survive while the Lambda is warm
+var (
+	routerMu     sync.Mutex
+	sharedRouter *api.Router
+)
+
 // Application errors
 var (
 	ErrConfigLoading   = errors.New("configuration loading failed")
@@ -31,18 +57,89 @@ var (
 
 // Configuration keys
 const (
-	GitHubOwnerKey = "github.owner"
-	GitHubRepoKey  = "github.repo"
-	GitHubPathKey  = "github.path"
-	GitHubTokenKey = "github.token"
-	DebugModeKey   = "debug.mode"
+	GitHubOwnerKey    = "github.owner"
+	GitHubRepoKey     = "github.repo"
+	GitHubPathKey     = "github.path"
+	GitHubRefKey      = "github.posts-ref"
+	GitHubTokenKey    = "github.token"
+	GitHubTrustKey    = "github.trust-level"
+	GitHubAppIDKey    = "github.app-id"
+	GitHubInstallKey  = "github.installation-id"
+	GitHubKeyKey      = "github.private-key"
+	GitHubKeyPathKey  = "github.private-key-path"
+	GitHubBaseURLKey  = "github.base-url"
+	GitHubUploadKey   = "github.upload-url"
+	GitHubCACertKey   = "github.ca-cert-path"
+	GitHubWebhookKey  = "github.webhook-secret"
+	DebugModeKey      = "debug.mode"
+	SiteURLKey        = "site.url"
+	PostPathKey       = "site.post-path"
+	SitemapURLKey     = "sitemap.url"
+	RobotsAllowKey    = "robots.allow"
+	RobotsDisallowKey = "robots.disallow"
+	WordsPerMinuteKey = "reading.words-per-minute"
+	HighlightStyleKey = "highlight.style"
+	LineNumbersKey    = "highlight.line-numbers"
+	CSSClassesKey     = "highlight.css-classes"
+	ExtensionsKey     = "markdown.extensions"
+	KrokiURLKey       = "diagrams.kroki-url"
+	AdmonitionsKey    = "markdown.admonition-types"
+	ValidationKey     = "markdown.frontmatter-validation"
+	AdminTokenKey     = "admin.token"
+	CacheTTLKey       = "cache.ttl"
+	SourceKey         = "posts.source"
+	OptSourcesKey     = "posts.optional-sources"
+	GitLabURLKey      = "gitlab.url"
+	GitLabProjectKey  = "gitlab.project"
+	GitLabPathKey     = "gitlab.path"
+	GitLabRefKey      = "gitlab.ref"
+	GitLabTokenKey    = "gitlab.token"
+	GitLabTrustKey    = "gitlab.trust-level"
+	GiteaURLKey       = "gitea.url"
+	GiteaOwnerKey     = "gitea.owner"
+	GiteaRepoKey      = "gitea.repo"
+	GiteaPathKey      = "gitea.path"
+	GiteaRefKey       = "gitea.ref"
+	GiteaTokenKey     = "gitea.token"
+	GiteaTrustKey     = "gitea.trust-level"
+	S3BucketKey       = "s3.bucket"
+	S3PrefixKey       = "s3.prefix"
+	S3EndpointKey     = "s3.endpoint"
+	S3TrustKey        = "s3.trust-level"
+	GitURLKey         = "git.url"
+	GitDirKey         = "git.dir"
+	GitPathKey        = "git.path"
+	GitRefKey         = "git.ref"
+	GitTokenKey       = "git.token"
+	GitTrustKey       = "git.trust-level"
 )
 
+// Post sources
+const (
+	SourceGitHub = "github"
+	SourceGitLab = "gitlab"
+	SourceGitea  = "gitea"
+	SourceS3     = "s3"
+	SourceGit    = "git"
+)
+
+// trustLevelKeys maps each post source to the key of its trust level
+var trustLevelKeys = map[string]string{
+	SourceGitHub: GitHubTrustKey,
+	SourceGitLab: GitLabTrustKey,
+	SourceGitea:  GiteaTrustKey,
+	SourceS3:     S3TrustKey,
+	SourceGit:    GitTrustKey,
+}
+
 // Default values
 const (
 	DefaultGitHubOwner = "buyallmemes"
 	DefaultGitHubRepo  = "blog-api"
 	DefaultGitHubPath  = "posts"
+	DefaultPostsPath   = "posts"
+	DefaultSiteURL     = "https://buyallmemes.com"
+	DefaultPostPath    = "/posts/"
 	DefaultTimeout     = 30 * time.Second
 )
 
@@ -60,6 +157,11 @@ func init() {
 		logger.Error("Failed to load application properties", "error", err)
 		os.Exit(1)
 	}
+
+	// Render diagrams through Kroki when configured, otherwise they are passed through to the client
+	if krokiURL := konfig.GetEnv(KrokiURLKey); krokiURL != "" {
+		diagramRenderer = markdown.NewCachedDiagramRenderer(markdown.NewKrokiRenderer(krokiURL, nil))
+	}
 }
 
 func main() {
@@ -71,61 +173,345 @@ func handler(ctx context.Context, request events.APIGatewayProxyRequest) (events
 	ctx, cancel := context.WithTimeout(ctx, DefaultTimeout)
 	defer cancel()
 
-	// Create the blog service
-	blogService, err := createBlogService()
+	router, err := getRouter(ctx)
 	if err != nil {
-		logger.Error("Error creating blog service", "error", err)
+		logger.Error("Error creating router", "error", err)
 		return createErrorResponse(http.StatusInternalServerError, "Internal server error"),
-			errors.Wrap(err, "error creating blog service")
+			errors.Wrap(err, "error creating router")
 	}
 
-	// Get all blog posts
-	blogData, err := blogService.GetAllPosts(ctx)
+	return router.Route(ctx, request)
+}
+
+// getRouter returns the shared router, creating it on first use. A failed creation is retried on the next request.
+func getRouter(ctx context.Context) (*api.Router, error) {
+	routerMu.Lock()
+	defer routerMu.Unlock()
+
+	if sharedRouter != nil {
+		return sharedRouter, nil
+	}
+
+	// Resolve secret references such as ssm:/blog/github-token in the configuration
+	awsConfig, err := awsconfig.LoadDefaultConfig(ctx)
 	if err != nil {
-		logger.Error("Error fetching blog posts", "error", err)
-		return createErrorResponse(http.StatusInternalServerError, "Error fetching blog posts"),
-			errors.Wrap(err, "error fetching blog posts")
+		return nil, errors.Wrap(err, "error loading AWS configuration")
 	}
+	resolver := secrets.NewDefaultResolver(awsConfig)
 
-	// Marshal the blog data to JSON
-	body, err := json.Marshal(blogData)
+	// Create the post repository
+	repository, err := createRepository(ctx, awsConfig, resolver)
 	if err != nil {
-		logger.Error("Error marshalling blog posts", "error", err)
-		return createErrorResponse(http.StatusInternalServerError, "Error processing blog posts"),
-			errors.Wrap(err, "error marshalling blog posts")
+		return nil, errors.Wrap(err, "error creating post repository")
 	}
 
-	// Return the successful response
-	return events.APIGatewayProxyResponse{
-		Body:       string(body),
-		StatusCode: http.StatusOK,
-		Headers: map[string]string{
-			"Content-Type": "application/json",
-		},
-	}, nil
+	adminToken, err := getSecret(ctx, resolver, AdminTokenKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error resolving admin token")
+	}
+
+	webhookSecret, err := getSecret(ctx, resolver, GitHubWebhookKey)
+	if err != nil {
+		return nil, errors.Wrap(err, "error resolving webhook secret")
+	}
+
+	// Create the router
+	created, err := createRouter(blogUsecase.NewBlogService(repository), repository, adminToken, webhookSecret)
+	if err != nil {
+		return nil, err
+	}
+
+	sharedRouter = created
+	return sharedRouter, nil
+}
+
+// createRepository creates the cached post repository with its dependencies
+func createRepository(ctx context.Context, awsConfig aws.Config, resolver *secrets.Resolver) (*cache.CachedRepository, error) {
+	// Resolve the enabled markdown extensions
+	extensions := markdown.DefaultExtensions()
+	if names := getEnvList(ExtensionsKey); len(names) > 0 {
+		parsed, err := markdown.ParseExtensions(names)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrServiceCreation, err)
+		}
+		extensions = parsed
+	}
+
+	// Resolve whether invalid frontmatter fails the post or is reported as warnings
+	validationMode, err := markdown.ParseValidationMode(konfig.GetEnv(ValidationKey))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreation, err)
+	}
+
+	// Resolve the supported callout types
+	admonitionTypes := markdown.DefaultAdmonitionTypes()
+	if types := getEnvList(AdmonitionsKey); len(types) > 0 {
+		admonitionTypes = types
+	}
+
+	// Configure the markdown parsers, each source gets its own for its trust level
+	parserOptions := []markdown.Option{
+		markdown.WithExtensions(extensions...),
+		markdown.WithAdmonitionTypes(admonitionTypes...),
+		markdown.WithValidationMode(validationMode),
+		markdown.WithDiagramRenderer(diagramRenderer),
+		markdown.WithWordsPerMinute(getEnvInt(WordsPerMinuteKey, markdown.DefaultWordsPerMinute)),
+		markdown.WithHighlighting(markdown.HighlightConfig{
+			Enabled:     true,
+			Style:       getEnvWithDefault(HighlightStyleKey, markdown.DefaultHighlightStyle),
+			CSSClasses:  getEnvBool(CSSClassesKey, true),
+			LineNumbers: getEnvBool(LineNumbersKey, false),
+		}),
+	}
+
+	// Create the repository of the configured sources
+	repository, err := createSources(ctx, awsConfig, resolver, parserOptions)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrServiceCreation, err)
+	}
+
+	// Cache the posts between invocations
+	return cache.NewCachedRepository(repository, getEnvDuration(CacheTTLKey, cache.DefaultTTL)), nil
 }
 
-// createBlogService creates and configures the blog service with its dependencies
-func createBlogService() (blogUsecase.BlogService, error) {
-	// Create the markdown parser
-	markdownParser := markdown.NewGoldmarkParser()
+// createSources creates the post repository of the configured sources.
+// Several sources are merged in the listed priority order, tolerating the failures of the optional ones.
+func createSources(ctx context.Context, awsConfig aws.Config, resolver *secrets.Resolver, parserOptions []markdown.Option) (blog.PostRepository, error) {
+	names := getEnvList(SourceKey)
+	if len(names) == 0 {
+		names = []string{SourceGitHub}
+	}
+	if len(names) == 1 {
+		return createSource(ctx, names[0], awsConfig, resolver, parserOptions)
+	}
+
+	optional := getEnvList(OptSourcesKey)
+	sources := make([]multi.Source, 0, len(names))
+	for _, name := range names {
+		repository, err := createSource(ctx, name, awsConfig, resolver, parserOptions)
+		if err != nil {
+			return nil, fmt.Errorf("error creating %s source: %w", name, err)
+		}
+		sources = append(sources, multi.Source{
+			Name:       name,
+			Repository: repository,
+			Optional:   slices.Contains(optional, name),
+		})
+	}
+	return multi.NewMultiRepository(sources...)
+}
+
+// createSource creates the post repository of a source with a markdown parser sanitizing its posts
+// at the trust level of the source, so guest sources never render with the trust of the blog owner
+func createSource(ctx context.Context, source string, awsConfig aws.Config, resolver *secrets.Resolver, parserOptions []markdown.Option) (blog.PostRepository, error) {
+	trustKey, ok := trustLevelKeys[source]
+	if !ok {
+		return nil, fmt.Errorf("unknown posts source %q", source)
+	}
+	trustLevel, err := markdown.ParseTrustLevel(konfig.GetEnv(trustKey))
+	if err != nil {
+		return nil, err
+	}
+	markdownParser := markdown.NewGoldmarkParser(append(slices.Clone(parserOptions), markdown.WithTrustLevel(trustLevel))...)
+
+	switch source {
+	case SourceGitHub:
+		return createGitHubRepository(ctx, resolver, markdownParser)
+	case SourceGitLab:
+		return createGitLabRepository(ctx, resolver, markdownParser)
+	case SourceGitea:
+		return createGiteaRepository(ctx, resolver, markdownParser)
+	case SourceS3:
+		return createS3Repository(awsConfig, markdownParser)
+	case SourceGit:
+		return createGitRepository(ctx, resolver, markdownParser)
+	default:
+		return nil, fmt.Errorf("unknown posts source %q", source)
+	}
+}
 
-	// Get GitHub configuration from environment variables
+// createGitHubRepository creates the GitHub repository from environment variables
+func createGitHubRepository(ctx context.Context, resolver *secrets.Resolver, markdownParser blog.MarkdownParser) (blog.PostRepository, error) {
+	token, err := getSecret(ctx, resolver, GitHubTokenKey)
+	if err != nil {
+		return nil, err
+	}
 	config := github.NewConfig(
 		getEnvWithDefault(GitHubOwnerKey, DefaultGitHubOwner),
 		getEnvWithDefault(GitHubRepoKey, DefaultGitHubRepo),
 		getEnvWithDefault(GitHubPathKey, DefaultGitHubPath),
-		konfig.GetEnv(GitHubTokenKey),
+		token,
 	)
+	config.Ref = konfig.GetEnv(GitHubRefKey)
 
-	// Create the repository
-	repository, err := github.NewGitHubRepository(markdownParser, config)
+	// Authenticate as a GitHub App installation when configured
+	appConfig, err := getGitHubAppConfig(ctx, resolver)
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrServiceCreation, err)
+		return nil, err
+	}
+	config.App = appConfig
+
+	// Point the client at a GitHub Enterprise server when configured
+	config.BaseURL = konfig.GetEnv(GitHubBaseURLKey)
+	config.UploadURL = konfig.GetEnv(GitHubUploadKey)
+	if caCertPath := konfig.GetEnv(GitHubCACertKey); caCertPath != "" {
+		if config.CACert, err = os.ReadFile(caCertPath); err != nil {
+			return nil, fmt.Errorf("error reading GitHub CA certificate: %w", err)
+		}
+	}
+
+	return github.NewGitHubRepository(markdownParser, config,
+		github.WithLogger(logger),
+		github.WithMetrics(metrics.NewPublisher(metrics.DefaultNamespace, nil)),
+	)
+}
+
+// createGitLabRepository creates the GitLab repository from environment variables
+func createGitLabRepository(ctx context.Context, resolver *secrets.Resolver, markdownParser blog.MarkdownParser) (blog.PostRepository, error) {
+	token, err := getSecret(ctx, resolver, GitLabTokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitlab.NewGitLabRepository(markdownParser, gitlab.NewConfig(
+		getEnvWithDefault(GitLabURLKey, gitlab.DefaultBaseURL),
+		konfig.GetEnv(GitLabProjectKey),
+		getEnvWithDefault(GitLabPathKey, DefaultPostsPath),
+		konfig.GetEnv(GitLabRefKey),
+		token,
+	))
+}
+
+// createGiteaRepository creates the Gitea repository from environment variables
+func createGiteaRepository(ctx context.Context, resolver *secrets.Resolver, markdownParser blog.MarkdownParser) (blog.PostRepository, error) {
+	token, err := getSecret(ctx, resolver, GiteaTokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return gitea.NewGiteaRepository(markdownParser, gitea.NewConfig(
+		konfig.GetEnv(GiteaURLKey),
+		konfig.GetEnv(GiteaOwnerKey),
+		konfig.GetEnv(GiteaRepoKey),
+		getEnvWithDefault(GiteaPathKey, DefaultPostsPath),
+		konfig.GetEnv(GiteaRefKey),
+		token,
+	))
+}
+
+// createS3Repository creates the S3 repository from environment variables.
+// A custom endpoint selects an S3-compatible store such as MinIO, addressed with path-style URLs.
+func createS3Repository(awsConfig aws.Config, markdownParser blog.MarkdownParser) (blog.PostRepository, error) {
+	client := awss3.NewFromConfig(awsConfig, func(options *awss3.Options) {
+		if endpoint := konfig.GetEnv(S3EndpointKey); endpoint != "" {
+			options.BaseEndpoint = aws.String(endpoint)
+			options.UsePathStyle = true
+		}
+	})
+
+	return s3.NewS3Repository(client, markdownParser, s3.NewConfig(
+		konfig.GetEnv(S3BucketKey),
+		getEnvWithDefault(S3PrefixKey, DefaultPostsPath+"/"),
+	))
+}
+
+// createGitRepository creates the git repository from environment variables, the remote is cloned on the first load
+func createGitRepository(ctx context.Context, resolver *secrets.Resolver, markdownParser blog.MarkdownParser) (blog.PostRepository, error) {
+	token, err := getSecret(ctx, resolver, GitTokenKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return git.NewGitRepository(markdownParser, git.NewConfig(
+		konfig.GetEnv(GitURLKey),
+		getEnvWithDefault(GitDirKey, git.DefaultDir),
+		getEnvWithDefault(GitPathKey, DefaultPostsPath),
+		konfig.GetEnv(GitRefKey),
+		token,
+	))
+}
+
+// getGitHubAppConfig reads the GitHub App credentials, returning nil when no app is configured.
+// The private key is read from the key path, or taken from the key itself, where escaped newlines are accepted.
+func getGitHubAppConfig(ctx context.Context, resolver *secrets.Resolver) (*github.AppConfig, error) {
+	appID := konfig.GetEnv(GitHubAppIDKey)
+	if appID == "" {
+		return nil, nil
+	}
+
+	app := &github.AppConfig{}
+	var err error
+	if app.AppID, err = strconv.ParseInt(appID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid GitHub App ID %q: %v", appID, err)
+	}
+
+	installationID := konfig.GetEnv(GitHubInstallKey)
+	if app.InstallationID, err = strconv.ParseInt(installationID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid GitHub App installation ID %q: %v", installationID, err)
+	}
+
+	if keyPath := konfig.GetEnv(GitHubKeyPathKey); keyPath != "" {
+		if app.PrivateKey, err = os.ReadFile(keyPath); err != nil {
+			return nil, fmt.Errorf("error reading GitHub App private key: %v", err)
+		}
+		return app, nil
+	}
+
+	key, err := getSecret(ctx, resolver, GitHubKeyKey)
+	if err != nil {
+		return nil, err
 	}
 
-	// Create and return the blog service
-	return blogUsecase.NewBlogService(repository), nil
+	// Environment variables often carry the PEM on a single line with escaped newlines
+	app.PrivateKey = []byte(strings.ReplaceAll(key, `\n`, "\n"))
+	return app, nil
+}
+
+// getSecret gets an environment variable, resolving it when it references a secret store
+func getSecret(ctx context.Context, resolver *secrets.Resolver, key string) (string, error) {
+	return resolver.Resolve(ctx, konfig.GetEnv(key))
+}
+
+// createRouter registers the API routes served by the blog service
+func createRouter(blogService blogUsecase.BlogService, repository *cache.CachedRepository, adminToken, webhookSecret string) (*api.Router, error) {
+	siteURL := strings.TrimSuffix(getEnvWithDefault(SiteURLKey, DefaultSiteURL), "/")
+
+	sitemap, err := seo.NewSitemapGenerator(siteURL, getEnvWithDefault(PostPathKey, DefaultPostPath))
+	if err != nil {
+		return nil, err
+	}
+
+	robots := seo.NewRobotsConfig(
+		getEnvWithDefault(SitemapURLKey, siteURL+"/sitemap.xml"),
+		getEnvList(RobotsAllowKey),
+		getEnvList(RobotsDisallowKey),
+	)
+
+	blogHandler := api.NewBlogHandler(blogService, logger)
+	seoHandler := api.NewSEOHandler(blogService, sitemap, robots, logger)
+	adminHandler := api.NewAdminHandler(blogService, adminToken, logger)
+	healthHandler := api.NewHealthHandler(repository, logger)
+	webhookHandler := api.NewWebhookHandler(repository, api.WebhookConfig{
+		Secret:     webhookSecret,
+		Repository: getEnvWithDefault(GitHubOwnerKey, DefaultGitHubOwner) + "/" + getEnvWithDefault(GitHubRepoKey, DefaultGitHubRepo),
+		Ref:        konfig.GetEnv(GitHubRefKey),
+		Path:       getEnvWithDefault(GitHubPathKey, DefaultGitHubPath),
+	}, logger)
+	assetsHandler := api.NewAssetsHandler(getEnvWithDefault(HighlightStyleKey, markdown.DefaultHighlightStyle), logger)
+
+	router := api.NewRouter()
+	router.Handle(http.MethodGet, "/healthz", healthHandler.GetHealth)
+	router.Handle(http.MethodGet, "/readyz", healthHandler.GetReadiness)
+	router.Handle(http.MethodGet, "/", blogHandler.GetAllPosts)
+	router.Handle(http.MethodGet, "/posts/{anchor}", blogHandler.GetPost)
+	router.Handle(http.MethodGet, "/posts/{anchor}/history", blogHandler.GetPostHistory)
+	router.Handle(http.MethodGet, "/sitemap.xml", seoHandler.GetSitemap)
+	router.Handle(http.MethodGet, "/robots.txt", seoHandler.GetRobots)
+	router.Handle(http.MethodGet, "/highlight.css", assetsHandler.GetHighlightCSS)
+	router.Handle(http.MethodGet, "/admin/load-report", adminHandler.GetLoadReport)
+	router.Handle(http.MethodPost, "/webhooks/github", webhookHandler.PostGitHub)
+
+	return router, nil
 }
 
 // createErrorResponse creates an API Gateway response for error cases
@@ -157,3 +543,59 @@ func getEnvWithDefault(key, defaultValue string) string {
 	}
 	return value
 }
+
+// getEnvList gets a comma-separated environment variable as a list of trimmed values
+func getEnvList(key string) []string {
+	var values []string
+	for _, value := range strings.Split(konfig.GetEnv(key), ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
+
+// getEnvInt gets an integer environment variable with a default value
+func getEnvInt(key string, defaultValue int) int {
+	value := konfig.GetEnv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.Atoi(value)
+	if err != nil {
+		logger.Warn("Invalid integer value, using default", "key", key, "value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvBool gets a boolean environment variable with a default value
+func getEnvBool(key string, defaultValue bool) bool {
+	value := konfig.GetEnv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := strconv.ParseBool(value)
+	if err != nil {
+		logger.Warn("Invalid boolean value, using default", "key", key, "value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
+
+// getEnvDuration gets a duration environment variable such as "5m" with a default value
+func getEnvDuration(key string, defaultValue time.Duration) time.Duration {
+	value := konfig.GetEnv(key)
+	if value == "" {
+		return defaultValue
+	}
+
+	parsed, err := time.ParseDuration(value)
+	if err != nil || parsed <= 0 {
+		logger.Warn("Invalid duration value, using default", "key", key, "value", value, "default", defaultValue)
+		return defaultValue
+	}
+	return parsed
+}
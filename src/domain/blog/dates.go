@@ -0,0 +1,28 @@
+package blog
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDate is returned when a date does not match any supported layout
+var ErrInvalidDate = errors.New("invalid date")
+
+// dateLayouts lists the date layouts accepted in post frontmatter
+var dateLayouts = []string{
+	"02.01.2006",
+	time.DateOnly,
+	time.RFC3339,
+}
+
+// ParseDate parses a frontmatter date using the supported layouts
+func ParseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	for _, layout := range dateLayouts {
+		if date, err := time.Parse(layout, value); err == nil {
+			return date, nil
+		}
+	}
+	return time.Time{}, ErrInvalidDate
+}
@@ -0,0 +1,25 @@
+package blog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseDate(t *testing.T) {
+	expected := time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC)
+
+	for _, value := range []string{"16.05.2024", "2024-05-16", "2024-05-16T00:00:00Z", " 16.05.2024 "} {
+		date, err := ParseDate(value)
+
+		assert.NoError(t, err, value)
+		assert.True(t, expected.Equal(date), value)
+	}
+}
+
+func TestParseDate_Invalid(t *testing.T) {
+	_, err := ParseDate("May 16th")
+
+	assert.ErrorIs(t, err, ErrInvalidDate)
+}
@@ -2,28 +2,106 @@ package blog
 
 import (
 	"encoding/json"
+	"slices"
+	"time"
 )
 
 // Post represents a blog post
 type Post struct {
-	Filename string `json:"filename"`
-	Content  string `json:"content"`
-	Date     string `json:"date"`
-	Title    string `json:"title"`
-	Anchor   string `json:"anchor"`
+	Filename  string    `json:"filename"`
+	Source    string    `json:"source,omitempty"`
+	Content   string    `json:"content"`
+	Date      string    `json:"date"`
+	UpdatedAt time.Time `json:"updated_at,omitzero"`
+	Title     string    `json:"title"`
+	Anchor    string    `json:"anchor"`
+	Aliases   []string  `json:"aliases,omitempty"`
+	Draft     bool      `json:"draft,omitempty"`
+	Unlisted  bool      `json:"unlisted,omitempty"`
+
+	Description  string   `json:"description,omitempty"`
+	Tags         []string `json:"tags,omitempty"`
+	Author       string   `json:"author,omitempty"`
+	Authors      []string `json:"authors,omitempty"`
+	CoverImage   string   `json:"cover_image,omitempty"`
+	CanonicalURL string   `json:"canonical_url,omitempty"`
+	Lang         string   `json:"lang,omitempty"`
+
+	TableOfContents []TOCEntry `json:"table_of_contents,omitempty"`
+
+	// Revisions lists the changes of the post, newest first, served by the history endpoint
+	Revisions []Revision `json:"-"`
+
+	WordCount          int `json:"word_count"`
+	ReadingTimeMinutes int `json:"reading_time_minutes"`
+	ImageCount         int `json:"image_count"`
+	CodeBlockCount     int `json:"code_block_count"`
+
+	// Markdown is the raw source of the post, PlainText its text without markup
+	Markdown  string `json:"-"`
+	PlainText string `json:"-"`
+
+	// Warnings lists the non-fatal problems found while parsing the post
+	Warnings []string `json:"-"`
+}
+
+// Revision represents a change of a post in the history of its source
+type Revision struct {
+	SHA     string    `json:"sha"`
+	Date    time.Time `json:"date"`
+	Author  string    `json:"author"`
+	Message string    `json:"message"`
+
+	// URL links to the diff of the change, when the source has a web interface
+	URL string `json:"url,omitempty"`
+}
+
+// TOCEntry represents a heading in a post's table of contents
+type TOCEntry struct {
+	Level    int        `json:"level"`
+	Text     string     `json:"text"`
+	ID       string     `json:"id"`
+	Children []TOCEntry `json:"children,omitempty"`
 }
 
 // ParsedMarkdown represents the result of parsing markdown content
 type ParsedMarkdown struct {
-	Content string
-	Title   string
-	Date    string
-	Anchor  string
+	Content   string
+	Title     string
+	Date      string
+	UpdatedAt time.Time
+	Anchor    string
+	Aliases   []string
+	Draft     bool
+	Unlisted  bool
+
+	Description  string
+	Tags         []string
+	Author       string
+	CoverImage   string
+	CanonicalURL string
+	Lang         string
+
+	TableOfContents []TOCEntry
+
+	WordCount          int
+	ReadingTimeMinutes int
+	ImageCount         int
+	CodeBlockCount     int
+
+	// PlainText is the text of the document without markup
+	PlainText string
+
+	// Warnings lists the non-fatal problems found while parsing, e.g. invalid TeX or frontmatter
+	Warnings []string
 }
 
 // Blog represents a collection of blog posts
 type Blog struct {
 	Posts []Post `json:"posts"`
+
+	// Report lists the posts that failed to load
+	Report LoadReport `json:"-"`
 }
 
 // NewBlog creates a new Blog instance
@@ -33,6 +111,85 @@ func NewBlog() *Blog {
 	}
 }
 
+// NewPost creates a new Post from a file name, its markdown source and the parsed result
+func NewPost(filename, source string, parsed ParsedMarkdown) Post {
+	return Post{
+		Filename:  filename,
+		Content:   parsed.Content,
+		Date:      parsed.Date,
+		UpdatedAt: parsed.UpdatedAt,
+		Title:     parsed.Title,
+		Anchor:    parsed.Anchor,
+		Aliases:   parsed.Aliases,
+		Draft:     parsed.Draft,
+		Unlisted:  parsed.Unlisted,
+
+		Description:  parsed.Description,
+		Tags:         parsed.Tags,
+		Author:       parsed.Author,
+		CoverImage:   parsed.CoverImage,
+		CanonicalURL: parsed.CanonicalURL,
+		Lang:         parsed.Lang,
+
+		TableOfContents: parsed.TableOfContents,
+
+		WordCount:          parsed.WordCount,
+		ReadingTimeMinutes: parsed.ReadingTimeMinutes,
+		ImageCount:         parsed.ImageCount,
+		CodeBlockCount:     parsed.CodeBlockCount,
+
+		Markdown:  source,
+		PlainText: parsed.PlainText,
+		Warnings:  parsed.Warnings,
+	}
+}
+
+// IsIndexable reports whether the post may be listed for search engines
+func (p Post) IsIndexable() bool {
+	return !p.Draft && !p.Unlisted && p.Anchor != ""
+}
+
+// HasAnchor reports whether the post answers to the anchor, either its own or one of its aliases
+func (p Post) HasAnchor(anchor string) bool {
+	return p.Anchor == anchor || slices.Contains(p.Aliases, anchor)
+}
+
+// SetRevisions sets the revisions of the post, newest first. Posts without an updated date in their frontmatter are
+// dated by the newest revision, and the authors of the revisions are listed in the order of their first change.
+// Posts without an author are attributed to the author of the oldest revision.
+func (p *Post) SetRevisions(revisions []Revision) {
+	p.Revisions = revisions
+	if len(revisions) == 0 {
+		return
+	}
+
+	if p.UpdatedAt.IsZero() {
+		p.UpdatedAt = revisions[0].Date
+	}
+
+	p.Authors = nil
+	for i := len(revisions) - 1; i >= 0; i-- {
+		if author := revisions[i].Author; author != "" && !slices.Contains(p.Authors, author) {
+			p.Authors = append(p.Authors, author)
+		}
+	}
+	if p.Author == "" && len(p.Authors) > 0 {
+		p.Author = p.Authors[0]
+	}
+}
+
+// LastModified returns the last update time of the post, falling back to its publication date
+func (p Post) LastModified() time.Time {
+	if !p.UpdatedAt.IsZero() {
+		return p.UpdatedAt
+	}
+	date, err := ParseDate(p.Date)
+	if err != nil {
+		return time.Time{}
+	}
+	return date
+}
+
 // MarshalJSON implements the json.Marshaler interface to ensure Posts is never null in JSON
 func (b Blog) MarshalJSON() ([]byte, error) {
 	type Alias Blog
@@ -3,6 +3,7 @@ package blog
 import (
 	"encoding/json"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -103,3 +104,79 @@ func TestNilBlogSerialization(t *testing.T) {
 	assert.NotNil(t, deserializedBlog.Posts)
 	assert.Empty(t, deserializedBlog.Posts)
 }
+
+func TestNewPost(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+	parsed := ParsedMarkdown{
+		Content:   "<p>Test content</p>",
+		Title:     "Test Post",
+		Date:      "2024-05-17",
+		UpdatedAt: updatedAt,
+		Anchor:    "test-post",
+		Draft:     true,
+		PlainText: "Test content",
+	}
+
+	post := NewPost("test.md", "# Test content", parsed)
+
+	assert.Equal(t, "test.md", post.Filename)
+	assert.Equal(t, "<p>Test content</p>", post.Content)
+	assert.Equal(t, "Test Post", post.Title)
+	assert.Equal(t, "2024-05-17", post.Date)
+	assert.Equal(t, updatedAt, post.UpdatedAt)
+	assert.Equal(t, "test-post", post.Anchor)
+	assert.True(t, post.Draft)
+	assert.False(t, post.Unlisted)
+	assert.Equal(t, "# Test content", post.Markdown)
+	assert.Equal(t, "Test content", post.PlainText)
+}
+
+func TestPost_IsIndexable(t *testing.T) {
+	assert.True(t, Post{Anchor: "test-post"}.IsIndexable())
+	assert.False(t, Post{Anchor: "test-post", Draft: true}.IsIndexable())
+	assert.False(t, Post{Anchor: "test-post", Unlisted: true}.IsIndexable())
+	assert.False(t, Post{}.IsIndexable())
+}
+
+func TestPost_LastModified(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, updatedAt, Post{Date: "16.05.2024", UpdatedAt: updatedAt}.LastModified())
+	assert.Equal(t, time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), Post{Date: "16.05.2024"}.LastModified())
+	assert.True(t, Post{Date: "someday"}.LastModified().IsZero())
+}
+
+func TestPost_HasAnchor(t *testing.T) {
+	post := Post{Anchor: "testing-guideline", Aliases: []string{"ultimate-testing-guideline"}}
+
+	assert.True(t, post.HasAnchor("testing-guideline"))
+	assert.True(t, post.HasAnchor("ultimate-testing-guideline"))
+	assert.False(t, post.HasAnchor("testing"))
+}
+
+func TestPost_SetRevisions(t *testing.T) {
+	revisions := []Revision{
+		{SHA: "c3", Date: time.Date(2024, 6, 2, 0, 0, 0, 0, time.UTC), Author: "alice"},
+		{SHA: "c2", Date: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), Author: "bob"},
+		{SHA: "c1", Date: time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC), Author: "alice"},
+	}
+
+	post := Post{}
+	post.SetRevisions(revisions)
+	assert.Equal(t, revisions, post.Revisions)
+	assert.Equal(t, revisions[0].Date, post.UpdatedAt)
+	assert.Equal(t, []string{"alice", "bob"}, post.Authors)
+	assert.Equal(t, "alice", post.Author)
+
+	// The frontmatter takes precedence
+	updatedAt := time.Date(2024, 7, 1, 0, 0, 0, 0, time.UTC)
+	post = Post{UpdatedAt: updatedAt, Author: "Carol"}
+	post.SetRevisions(revisions)
+	assert.Equal(t, updatedAt, post.UpdatedAt)
+	assert.Equal(t, "Carol", post.Author)
+
+	post = Post{}
+	post.SetRevisions(nil)
+	assert.True(t, post.UpdatedAt.IsZero())
+	assert.Empty(t, post.Authors)
+}
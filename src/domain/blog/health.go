@@ -0,0 +1,19 @@
+package blog
+
+import (
+	"context"
+)
+
+// HealthCheck is the outcome of checking one dependency of the blog
+type HealthCheck struct {
+	Name    string         `json:"name"`
+	Healthy bool           `json:"healthy"`
+	Message string         `json:"message,omitempty"`
+	Details map[string]any `json:"details,omitempty"`
+}
+
+// HealthChecker is implemented by repositories that can check the health of their source
+type HealthChecker interface {
+	// CheckHealth checks the source without loading the posts
+	CheckHealth(ctx context.Context) []HealthCheck
+}
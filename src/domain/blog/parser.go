@@ -1,7 +1,21 @@
 package blog
 
+import (
+	"context"
+)
+
+// Document is the markdown source of a post
+type Document struct {
+	// Filename identifies the document in parse errors
+	Filename string
+	Content  string
+
+	// Files reads the files the document references, like included code samples
+	Files FileReader
+}
+
 // MarkdownParser defines the interface for parsing markdown content
 type MarkdownParser interface {
-	// ParseMarkdown parses markdown content and returns parsed markdown data
-	ParseMarkdown(content string) (ParsedMarkdown, error)
+	// ParseDocument parses a markdown document and returns parsed markdown data
+	ParseDocument(ctx context.Context, document Document) (ParsedMarkdown, error)
 }
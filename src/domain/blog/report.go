@@ -0,0 +1,76 @@
+package blog
+
+import (
+	"time"
+)
+
+// LoadErrorKind classifies why a post failed to load
+type LoadErrorKind string
+
+// Load error kinds
+const (
+	// LoadErrorFetch means the file could not be read from the source
+	LoadErrorFetch LoadErrorKind = "fetch"
+	// LoadErrorDecode means the file content could not be decoded
+	LoadErrorDecode LoadErrorKind = "decode"
+	// LoadErrorParse means the markdown or its frontmatter could not be parsed
+	LoadErrorParse LoadErrorKind = "parse"
+	// LoadErrorDuplicateAnchor means another post already uses the anchor or an alias of the post
+	LoadErrorDuplicateAnchor LoadErrorKind = "duplicate_anchor"
+	// LoadErrorSource means a whole source of posts failed to load
+	LoadErrorSource LoadErrorKind = "source"
+)
+
+// LoadError describes a post that was skipped because it failed to load
+type LoadError struct {
+	Filename string        `json:"filename"`
+	Kind     LoadErrorKind `json:"kind"`
+	Message  string        `json:"message"`
+}
+
+// LoadWarning describes a non-fatal problem of a loaded post
+type LoadWarning struct {
+	Filename string `json:"filename"`
+	Message  string `json:"message"`
+}
+
+// LoadReport summarizes a load of all posts
+type LoadReport struct {
+	LoadedAt time.Time     `json:"loaded_at"`
+	Posts    int           `json:"posts"`
+	Errors   []LoadError   `json:"errors"`
+	Warnings []LoadWarning `json:"warnings"`
+}
+
+// NewLoadReport creates an empty LoadReport for a load at the given time
+func NewLoadReport(loadedAt time.Time) LoadReport {
+	return LoadReport{
+		LoadedAt: loadedAt,
+		Errors:   []LoadError{},
+		Warnings: []LoadWarning{},
+	}
+}
+
+// AddError records a post that failed to load
+func (r *LoadReport) AddError(filename string, kind LoadErrorKind, err error) {
+	r.Errors = append(r.Errors, LoadError{
+		Filename: filename,
+		Kind:     kind,
+		Message:  err.Error(),
+	})
+}
+
+// AddWarnings records the warnings of a loaded post
+func (r *LoadReport) AddWarnings(filename string, warnings []string) {
+	for _, warning := range warnings {
+		r.Warnings = append(r.Warnings, LoadWarning{
+			Filename: filename,
+			Message:  warning,
+		})
+	}
+}
+
+// HasErrors reports whether any post failed to load
+func (r LoadReport) HasErrors() bool {
+	return len(r.Errors) > 0
+}
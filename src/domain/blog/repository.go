@@ -2,10 +2,54 @@ package blog
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+)
+
+// Common errors
+var (
+	ErrPostNotFound    = errors.New("post not found")
+	ErrDuplicateAnchor = errors.New("duplicate anchor")
+	ErrFileNotFound    = errors.New("file not found")
+	ErrInvalidPath     = errors.New("invalid file path")
+	ErrRateLimited     = errors.New("rate limit exceeded")
 )
 
 // PostRepository defines the interface for fetching blog posts
 type PostRepository interface {
-	// FetchPosts fetches all blog posts
-	FetchPosts(ctx context.Context) ([]Post, error)
+	// FetchPosts fetches all blog posts. Posts that fail to load are skipped and listed in the report,
+	// the error is reserved for failures of the source as a whole.
+	FetchPosts(ctx context.Context) ([]Post, LoadReport, error)
+}
+
+// PostFetcher is implemented by repositories able to fetch a single post
+type PostFetcher interface {
+	// FetchPost fetches the post of a file of the posts directory, returning ErrFileNotFound when it does not exist
+	FetchPost(ctx context.Context, filename string) (Post, error)
+}
+
+// PostRefresher refreshes posts served from a cache before it expires
+type PostRefresher interface {
+	// RefreshPosts refreshes the posts of the changed files of the posts directory, dropping the deleted ones
+	RefreshPosts(ctx context.Context, filenames []string) error
+
+	// Invalidate drops the cached posts, they are loaded again on the next request
+	Invalidate()
+}
+
+// FileReader reads files from the source the posts are fetched from
+type FileReader interface {
+	// ReadFile reads a file by its slash-separated path relative to the root of the source
+	ReadFile(ctx context.Context, path string) ([]byte, error)
+}
+
+// CleanPath validates a path read through a FileReader, rejecting absolute paths and paths escaping the root
+func CleanPath(name string) (string, error) {
+	cleaned := path.Clean(strings.TrimSpace(name))
+	if cleaned == "." || path.IsAbs(cleaned) || cleaned == ".." || strings.HasPrefix(cleaned, "../") {
+		return "", fmt.Errorf("%w: %q", ErrInvalidPath, name)
+	}
+	return cleaned, nil
 }
@@ -0,0 +1,35 @@
+package blog
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCleanPath(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     string
+		expected string
+		valid    bool
+	}{
+		{name: "relative path", path: "examples/main.go", expected: "examples/main.go", valid: true},
+		{name: "dot segments", path: "./examples/../examples/main.go", expected: "examples/main.go", valid: true},
+		{name: "absolute path", path: "/etc/passwd"},
+		{name: "escaping path", path: "../secrets.txt"},
+		{name: "empty path", path: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cleaned, err := CleanPath(tt.path)
+
+			if !tt.valid {
+				assert.ErrorIs(t, err, ErrInvalidPath)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, cleaned)
+		})
+	}
+}
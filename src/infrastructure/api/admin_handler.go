@@ -0,0 +1,68 @@
+package api
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	blogUsecase "buyallmemes.com/blog-api/src/usecase/blog"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+)
+
+// AdminHandler serves operational endpoints protected by a bearer token
+type AdminHandler struct {
+	blogService blogUsecase.BlogService
+	token       string
+	logger      *logging.Logger
+}
+
+// NewAdminHandler creates a new AdminHandler instance. Without a token the admin endpoints are disabled.
+func NewAdminHandler(blogService blogUsecase.BlogService, token string, logger *logging.Logger) *AdminHandler {
+	return &AdminHandler{
+		blogService: blogService,
+		token:       token,
+		logger:      logger,
+	}
+}
+
+// GetLoadReport returns the report of the posts that failed to load as JSON
+func (h *AdminHandler) GetLoadReport(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if response, ok := h.authorize(request); !ok {
+		return response, nil
+	}
+
+	report, err := h.blogService.GetLoadReport(ctx)
+	if err != nil {
+		h.logger.Error("Error loading blog posts", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error loading blog posts"),
+			errors.Wrap(err, "error loading blog posts")
+	}
+
+	body, err := json.Marshal(report)
+	if err != nil {
+		h.logger.Error("Error marshalling load report", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error processing load report"),
+			errors.Wrap(err, "error marshalling load report")
+	}
+
+	return createResponse(http.StatusOK, ContentTypeJSON, string(body)), nil
+}
+
+// authorize checks the bearer token of the request, returning the error response when it is rejected
+func (h *AdminHandler) authorize(request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, bool) {
+	if h.token == "" {
+		return createErrorResponse(http.StatusNotFound, "Not found"), false
+	}
+
+	token, ok := strings.CutPrefix(headerValue(request.Headers, "Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.token)) != 1 {
+		response := createErrorResponse(http.StatusUnauthorized, "Unauthorized")
+		response.Headers["WWW-Authenticate"] = "Bearer"
+		return response, false
+	}
+	return events.APIGatewayProxyResponse{}, true
+}
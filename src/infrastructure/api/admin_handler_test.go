@@ -0,0 +1,60 @@
+package api
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestAdminHandler(token string) *AdminHandler {
+	report := blog.NewLoadReport(time.Date(2024, 5, 16, 0, 0, 0, 0, time.UTC))
+	report.Posts = 1
+	report.AddError("broken.md", blog.LoadErrorParse, errors.New("invalid frontmatter"))
+	return NewAdminHandler(&StubBlogService{report: report}, token, logging.Default())
+}
+
+func TestAdminHandler_GetLoadReport(t *testing.T) {
+	response, err := newTestAdminHandler("secret").GetLoadReport(context.Background(), events.APIGatewayProxyRequest{
+		Headers: map[string]string{"authorization": "Bearer secret"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{
+		"loaded_at": "2024-05-16T00:00:00Z",
+		"posts": 1,
+		"errors": [{"filename": "broken.md", "kind": "parse", "message": "invalid frontmatter"}],
+		"warnings": []
+	}`, response.Body)
+}
+
+func TestAdminHandler_GetLoadReport_Unauthorized(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		headers map[string]string
+		status  int
+	}{
+		{name: "missing token", token: "secret", status: http.StatusUnauthorized},
+		{name: "wrong token", token: "secret", headers: map[string]string{"Authorization": "Bearer guess"}, status: http.StatusUnauthorized},
+		{name: "disabled", headers: map[string]string{"Authorization": "Bearer "}, status: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := newTestAdminHandler(tt.token).GetLoadReport(context.Background(), events.APIGatewayProxyRequest{
+				Headers: tt.headers,
+			})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, response.StatusCode)
+		})
+	}
+}
@@ -0,0 +1,45 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+)
+
+// AssetsHandler serves the static assets that accompany rendered posts
+type AssetsHandler struct {
+	highlightStyle string
+	logger         *logging.Logger
+}
+
+// NewAssetsHandler creates a new AssetsHandler instance
+func NewAssetsHandler(highlightStyle string, logger *logging.Logger) *AssetsHandler {
+	return &AssetsHandler{
+		highlightStyle: highlightStyle,
+		logger:         logger,
+	}
+}
+
+// GetHighlightCSS returns the code highlighting stylesheet, ?style=name switches the theme
+func (h *AssetsHandler) GetHighlightCSS(_ context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	style := h.highlightStyle
+	if value := request.QueryStringParameters["style"]; value != "" {
+		style = value
+	}
+
+	css, err := markdown.HighlightCSS(style)
+	if errors.Is(err, markdown.ErrUnknownHighlightStyle) {
+		return createErrorResponse(http.StatusNotFound, "Unknown highlight style"), nil
+	}
+	if err != nil {
+		h.logger.Error("Error generating highlight CSS", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error generating highlight CSS"),
+			errors.Wrap(err, "error generating highlight CSS")
+	}
+
+	return createResponse(http.StatusOK, ContentTypeCSS, css), nil
+}
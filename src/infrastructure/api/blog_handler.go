@@ -0,0 +1,181 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	blogUsecase "buyallmemes.com/blog-api/src/usecase/blog"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+)
+
+// BlogHandler serves blog posts
+type BlogHandler struct {
+	blogService blogUsecase.BlogService
+	logger      *logging.Logger
+}
+
+// NewBlogHandler creates a new BlogHandler instance
+func NewBlogHandler(blogService blogUsecase.BlogService, logger *logging.Logger) *BlogHandler {
+	return &BlogHandler{
+		blogService: blogService,
+		logger:      logger,
+	}
+}
+
+// GetAllPosts returns all blog posts as JSON
+func (h *BlogHandler) GetAllPosts(ctx context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Get all blog posts
+	blogData, err := h.blogService.GetAllPosts(ctx)
+	if err != nil {
+		h.logger.Error("Error fetching blog posts", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error fetching blog posts"),
+			errors.Wrap(err, "error fetching blog posts")
+	}
+
+	logLoadReport(h.logger, blogData.Report)
+
+	// Marshal the blog data to JSON
+	body, err := json.Marshal(blogData)
+	if err != nil {
+		h.logger.Error("Error marshalling blog posts", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error processing blog posts"),
+			errors.Wrap(err, "error marshalling blog posts")
+	}
+
+	return createResponse(http.StatusOK, ContentTypeJSON, string(body)), nil
+}
+
+// logLoadReport logs the posts that were skipped because they failed to load
+func logLoadReport(logger *logging.Logger, report blog.LoadReport) {
+	for _, loadError := range report.Errors {
+		logger.Warn("Skipped post that failed to load",
+			"filename", loadError.Filename,
+			"kind", loadError.Kind,
+			"error", loadError.Message,
+		)
+	}
+}
+
+// GetPost returns a single post as JSON, HTML, markdown or plain text.
+// The format is taken from ?format= or negotiated from the Accept header.
+func (h *BlogHandler) GetPost(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	format, err := negotiateFormat(request.QueryStringParameters["format"], headerValue(request.Headers, "Accept"))
+	if errors.Is(err, ErrUnknownFormat) {
+		return createErrorResponse(http.StatusBadRequest, "Unknown format"), nil
+	}
+	if errors.Is(err, ErrNotAcceptable) {
+		return createErrorResponse(http.StatusNotAcceptable, "Not acceptable"), nil
+	}
+
+	post, err := h.blogService.GetPost(ctx, request.PathParameters["anchor"])
+	if errors.Is(err, blog.ErrPostNotFound) {
+		return createErrorResponse(http.StatusNotFound, "Post not found"), nil
+	}
+	if err != nil {
+		h.logger.Error("Error fetching blog post", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error fetching blog post"),
+			errors.Wrap(err, "error fetching blog post")
+	}
+
+	// Old anchors listed as aliases permanently redirect to the current one
+	if requested := request.PathParameters["anchor"]; post.Anchor != requested {
+		return createRedirectResponse(http.StatusMovedPermanently, postLocation(request, post.Anchor, "")), nil
+	}
+
+	var response events.APIGatewayProxyResponse
+	switch format {
+	case FormatHTML:
+		response = createResponse(http.StatusOK, ContentTypeHTML, post.Content)
+	case FormatMarkdown:
+		response = createResponse(http.StatusOK, ContentTypeMarkdown, post.Markdown)
+	case FormatText:
+		response = createResponse(http.StatusOK, ContentTypePlain, post.PlainText)
+	default:
+		body, err := json.Marshal(post)
+		if err != nil {
+			h.logger.Error("Error marshalling blog post", "error", err)
+			return createErrorResponse(http.StatusInternalServerError, "Error processing blog post"),
+				errors.Wrap(err, "error marshalling blog post")
+		}
+		response = createResponse(http.StatusOK, ContentTypeJSON, string(body))
+	}
+
+	// The representation depends on the Accept header, shared caches must key on it
+	response.Headers["Vary"] = "Accept"
+	return response, nil
+}
+
+// postHistory is the revision history of a post
+type postHistory struct {
+	Anchor    string          `json:"anchor"`
+	Title     string          `json:"title"`
+	Date      string          `json:"date"`
+	UpdatedAt time.Time       `json:"updated_at,omitzero"`
+	Authors   []string        `json:"authors,omitempty"`
+	Revisions []blog.Revision `json:"revisions"`
+}
+
+// GetPostHistory returns the revisions of a post as JSON, newest first, linking to their diffs where the source can
+func (h *BlogHandler) GetPostHistory(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	post, err := h.blogService.GetPost(ctx, request.PathParameters["anchor"])
+	if errors.Is(err, blog.ErrPostNotFound) {
+		return createErrorResponse(http.StatusNotFound, "Post not found"), nil
+	}
+	if err != nil {
+		h.logger.Error("Error fetching blog post", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error fetching blog post"),
+			errors.Wrap(err, "error fetching blog post")
+	}
+
+	// Old anchors listed as aliases permanently redirect to the history of the current one
+	if requested := request.PathParameters["anchor"]; post.Anchor != requested {
+		return createRedirectResponse(http.StatusMovedPermanently, postLocation(request, post.Anchor, "/history")), nil
+	}
+
+	history := postHistory{
+		Anchor:    post.Anchor,
+		Title:     post.Title,
+		Date:      post.Date,
+		UpdatedAt: post.UpdatedAt,
+		Authors:   post.Authors,
+		Revisions: post.Revisions,
+	}
+	if history.Revisions == nil {
+		history.Revisions = []blog.Revision{}
+	}
+
+	body, err := json.Marshal(history)
+	if err != nil {
+		h.logger.Error("Error marshalling post history", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error processing post history"),
+			errors.Wrap(err, "error marshalling post history")
+	}
+	return createResponse(http.StatusOK, ContentTypeJSON, string(body)), nil
+}
+
+// postLocation returns the location of the post with the given anchor followed by the suffix, keeping the query of
+// the request. The location is relative to the request URL, which keeps the stage prefix API Gateway strips from
+// the request path, e.g. /Prod/posts/{anchor}.
+func postLocation(request events.APIGatewayProxyRequest, anchor, suffix string) string {
+	// Climb out of the segments of the suffix, the request URL resolves relative locations against its last segment
+	location := "./" + anchor + suffix
+	for range strings.Count(suffix, "/") {
+		location = "../" + strings.TrimPrefix(location, "./")
+	}
+
+	query := url.Values{}
+	for key, value := range request.QueryStringParameters {
+		query.Set(key, value)
+	}
+	if len(query) > 0 {
+		location += "?" + query.Encode()
+	}
+	return location
+}
@@ -0,0 +1,232 @@
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubBlogService is a stub implementation of the BlogService interface
+type StubBlogService struct {
+	posts  []blog.Post
+	report blog.LoadReport
+	err    error
+}
+
+func (s *StubBlogService) GetAllPosts(_ context.Context) (*blog.Blog, error) {
+	return &blog.Blog{Posts: s.posts, Report: s.report}, s.err
+}
+
+func (s *StubBlogService) GetLoadReport(_ context.Context) (*blog.LoadReport, error) {
+	return &s.report, s.err
+}
+
+func (s *StubBlogService) GetPost(_ context.Context, anchor string) (*blog.Post, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	for i := range s.posts {
+		if s.posts[i].HasAnchor(anchor) {
+			return &s.posts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", blog.ErrPostNotFound, anchor)
+}
+
+func newTestBlogHandler() *BlogHandler {
+	service := &StubBlogService{posts: []blog.Post{{
+		Filename:  "20240516-test.md",
+		Title:     "Test Post",
+		Anchor:    "test-post",
+		Aliases:   []string{"old-test-post"},
+		Content:   "<h1 id=\"test-post\">Test Post</h1>",
+		Markdown:  "# Test Post",
+		PlainText: "Test Post",
+	}}}
+	return NewBlogHandler(service, logging.Default())
+}
+
+func TestBlogHandler_GetPost_Formats(t *testing.T) {
+	tests := []struct {
+		name        string
+		request     events.APIGatewayProxyRequest
+		contentType string
+		body        string
+	}{
+		{
+			name:        "html",
+			request:     events.APIGatewayProxyRequest{Headers: map[string]string{"accept": "text/html"}},
+			contentType: ContentTypeHTML,
+			body:        "<h1 id=\"test-post\">Test Post</h1>",
+		},
+		{
+			name:        "markdown",
+			request:     events.APIGatewayProxyRequest{Headers: map[string]string{"Accept": "text/markdown"}},
+			contentType: ContentTypeMarkdown,
+			body:        "# Test Post",
+		},
+		{
+			name:        "plain text",
+			request:     events.APIGatewayProxyRequest{QueryStringParameters: map[string]string{"format": "text"}},
+			contentType: ContentTypePlain,
+			body:        "Test Post",
+		},
+		{
+			name:        "json",
+			request:     events.APIGatewayProxyRequest{},
+			contentType: ContentTypeJSON,
+			body:        `"anchor":"test-post"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.request.PathParameters = map[string]string{"anchor": "test-post"}
+
+			response, err := newTestBlogHandler().GetPost(context.Background(), tt.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.Equal(t, tt.contentType, response.Headers["Content-Type"])
+			assert.Equal(t, "Accept", response.Headers["Vary"])
+			assert.Contains(t, response.Body, tt.body)
+		})
+	}
+}
+
+func TestBlogHandler_GetPost_Errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		request events.APIGatewayProxyRequest
+		status  int
+	}{
+		{
+			name:    "not found",
+			request: events.APIGatewayProxyRequest{PathParameters: map[string]string{"anchor": "missing"}},
+			status:  http.StatusNotFound,
+		},
+		{
+			name: "unknown format",
+			request: events.APIGatewayProxyRequest{
+				PathParameters:        map[string]string{"anchor": "test-post"},
+				QueryStringParameters: map[string]string{"format": "pdf"},
+			},
+			status: http.StatusBadRequest,
+		},
+		{
+			name: "not acceptable",
+			request: events.APIGatewayProxyRequest{
+				PathParameters: map[string]string{"anchor": "test-post"},
+				Headers:        map[string]string{"Accept": "image/png"},
+			},
+			status: http.StatusNotAcceptable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			response, err := newTestBlogHandler().GetPost(context.Background(), tt.request)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, response.StatusCode)
+		})
+	}
+}
+
+func TestBlogHandler_GetPost_RedirectsAlias(t *testing.T) {
+	response, err := newTestBlogHandler().GetPost(context.Background(), events.APIGatewayProxyRequest{
+		Path:                  "/posts/old-test-post",
+		PathParameters:        map[string]string{"anchor": "old-test-post"},
+		QueryStringParameters: map[string]string{"format": "markdown"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, response.StatusCode)
+	assert.Equal(t, "./test-post?format=markdown", response.Headers["Location"])
+
+	// The location resolves against the request URL, keeping the stage of API Gateway URLs
+	base, err := url.Parse("https://abc123.execute-api.eu-west-1.amazonaws.com/Prod/posts/old-test-post?format=markdown")
+	assert.NoError(t, err)
+	location, err := base.Parse(response.Headers["Location"])
+	assert.NoError(t, err)
+	assert.Equal(t, "/Prod/posts/test-post", location.Path)
+}
+
+func TestBlogHandler_GetPostHistory(t *testing.T) {
+	updatedAt := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	service := &StubBlogService{posts: []blog.Post{{
+		Title:     "Test Post",
+		Anchor:    "test-post",
+		Aliases:   []string{"old-test-post"},
+		Date:      "16.05.2024",
+		UpdatedAt: updatedAt,
+		Authors:   []string{"alice"},
+		Revisions: []blog.Revision{{
+			SHA:     "c1",
+			Date:    updatedAt,
+			Author:  "alice",
+			Message: "Add test post",
+			URL:     "https://github.com/owner/repo/commit/c1",
+		}},
+	}, {
+		Title:  "Untracked Post",
+		Anchor: "untracked-post",
+	}}}
+	handler := NewBlogHandler(service, logging.Default())
+
+	response, err := handler.GetPostHistory(context.Background(), events.APIGatewayProxyRequest{
+		Path:           "/posts/test-post/history",
+		PathParameters: map[string]string{"anchor": "test-post"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{
+		"anchor": "test-post",
+		"title": "Test Post",
+		"date": "16.05.2024",
+		"updated_at": "2024-06-01T12:00:00Z",
+		"authors": ["alice"],
+		"revisions": [{
+			"sha": "c1",
+			"date": "2024-06-01T12:00:00Z",
+			"author": "alice",
+			"message": "Add test post",
+			"url": "https://github.com/owner/repo/commit/c1"
+		}]
+	}`, response.Body)
+
+	response, err = handler.GetPostHistory(context.Background(), events.APIGatewayProxyRequest{
+		Path:           "/posts/untracked-post/history",
+		PathParameters: map[string]string{"anchor": "untracked-post"},
+	})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"anchor": "untracked-post", "title": "Untracked Post", "date": "", "revisions": []}`, response.Body)
+
+	response, err = handler.GetPostHistory(context.Background(), events.APIGatewayProxyRequest{
+		Path:           "/posts/old-test-post/history",
+		PathParameters: map[string]string{"anchor": "old-test-post"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMovedPermanently, response.StatusCode)
+	assert.Equal(t, "../test-post/history", response.Headers["Location"])
+	base, err := url.Parse("https://abc123.execute-api.eu-west-1.amazonaws.com/Prod/posts/old-test-post/history")
+	assert.NoError(t, err)
+	location, err := base.Parse(response.Headers["Location"])
+	assert.NoError(t, err)
+	assert.Equal(t, "/Prod/posts/test-post/history", location.Path)
+
+	response, err = handler.GetPostHistory(context.Background(), events.APIGatewayProxyRequest{
+		Path:           "/posts/missing/history",
+		PathParameters: map[string]string{"anchor": "missing"},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
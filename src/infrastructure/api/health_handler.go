@@ -0,0 +1,76 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+)
+
+// Health statuses
+const (
+	StatusOK   = "ok"
+	StatusFail = "fail"
+)
+
+// healthResponse is the JSON body of the health endpoints
+type healthResponse struct {
+	Status string             `json:"status"`
+	Checks []blog.HealthCheck `json:"checks,omitempty"`
+}
+
+// HealthHandler serves the liveness and readiness endpoints
+type HealthHandler struct {
+	checker blog.HealthChecker
+	logger  *logging.Logger
+}
+
+// NewHealthHandler creates a new HealthHandler instance
+func NewHealthHandler(checker blog.HealthChecker, logger *logging.Logger) *HealthHandler {
+	return &HealthHandler{
+		checker: checker,
+		logger:  logger,
+	}
+}
+
+// GetHealth reports that the process is alive
+func (h *HealthHandler) GetHealth(_ context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return h.respond(healthResponse{Status: StatusOK})
+}
+
+// GetReadiness checks the post repository, the cache and the last load, answering 503 when any check fails
+func (h *HealthHandler) GetReadiness(ctx context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	response := healthResponse{
+		Status: StatusOK,
+		Checks: h.checker.CheckHealth(ctx),
+	}
+
+	for _, check := range response.Checks {
+		if !check.Healthy {
+			response.Status = StatusFail
+			h.logger.Warn("Readiness check failed", "check", check.Name, "message", check.Message)
+		}
+	}
+
+	return h.respond(response)
+}
+
+// respond marshals the health response, using 503 for failed checks
+func (h *HealthHandler) respond(response healthResponse) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		h.logger.Error("Error marshalling health response", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error processing health checks"),
+			errors.Wrap(err, "error marshalling health response")
+	}
+
+	status := http.StatusOK
+	if response.Status != StatusOK {
+		status = http.StatusServiceUnavailable
+	}
+	return createResponse(status, ContentTypeJSON, string(body)), nil
+}
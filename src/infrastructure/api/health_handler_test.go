@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubHealthChecker is a stub implementation of the HealthChecker interface
+type StubHealthChecker struct {
+	checks []blog.HealthCheck
+}
+
+func (s *StubHealthChecker) CheckHealth(_ context.Context) []blog.HealthCheck {
+	return s.checks
+}
+
+func TestHealthHandler_GetHealth(t *testing.T) {
+	handler := NewHealthHandler(&StubHealthChecker{}, logging.Default())
+
+	response, err := handler.GetHealth(context.Background(), events.APIGatewayProxyRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{"status": "ok"}`, response.Body)
+}
+
+func TestHealthHandler_GetReadiness(t *testing.T) {
+	tests := []struct {
+		name   string
+		checks []blog.HealthCheck
+		status int
+		body   string
+	}{
+		{
+			name:   "ready",
+			checks: []blog.HealthCheck{{Name: "github", Healthy: true}, {Name: "cache", Healthy: true}},
+			status: http.StatusOK,
+			body:   `{"status": "ok", "checks": [{"name": "github", "healthy": true}, {"name": "cache", "healthy": true}]}`,
+		},
+		{
+			name:   "not ready",
+			checks: []blog.HealthCheck{{Name: "github", Message: "rate limit exhausted"}, {Name: "cache", Healthy: true}},
+			status: http.StatusServiceUnavailable,
+			body:   `{"status": "fail", "checks": [{"name": "github", "healthy": false, "message": "rate limit exhausted"}, {"name": "cache", "healthy": true}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := NewHealthHandler(&StubHealthChecker{checks: tt.checks}, logging.Default())
+
+			response, err := handler.GetReadiness(context.Background(), events.APIGatewayProxyRequest{})
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.status, response.StatusCode)
+			assert.JSONEq(t, tt.body, response.Body)
+		})
+	}
+}
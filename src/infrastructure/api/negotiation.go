@@ -0,0 +1,102 @@
+package api
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// Format is an output format of a single post
+type Format string
+
+// Supported post formats
+const (
+	FormatJSON     Format = "json"
+	FormatHTML     Format = "html"
+	FormatMarkdown Format = "markdown"
+	FormatText     Format = "text"
+)
+
+// Common errors
+var (
+	ErrUnknownFormat = errors.New("unknown format")
+	ErrNotAcceptable = errors.New("not acceptable")
+)
+
+// formatMediaTypes maps the media types of the Accept header to formats,
+// the order decides between formats accepted with the same quality
+var formatMediaTypes = []struct {
+	mediaType string
+	format    Format
+}{
+	{"application/json", FormatJSON},
+	{"text/html", FormatHTML},
+	{"text/markdown", FormatMarkdown},
+	{"text/plain", FormatText},
+}
+
+// negotiateFormat picks the post format from the ?format= parameter, falling back to the Accept header.
+// Without either, posts are served as JSON.
+func negotiateFormat(formatParam, accept string) (Format, error) {
+	if formatParam != "" {
+		format := Format(strings.ToLower(strings.TrimSpace(formatParam)))
+		for _, candidate := range formatMediaTypes {
+			if candidate.format == format {
+				return format, nil
+			}
+		}
+		return "", ErrUnknownFormat
+	}
+
+	if strings.TrimSpace(accept) == "" {
+		return FormatJSON, nil
+	}
+
+	best, bestQuality := Format(""), 0.0
+	for _, candidate := range formatMediaTypes {
+		if quality := acceptQuality(accept, candidate.mediaType); quality > bestQuality {
+			best, bestQuality = candidate.format, quality
+		}
+	}
+	if best == "" {
+		return "", ErrNotAcceptable
+	}
+	return best, nil
+}
+
+// acceptQuality returns the quality the Accept header assigns to a media type,
+// using the most specific matching range
+func acceptQuality(accept, mediaType string) float64 {
+	mainType, _, _ := strings.Cut(mediaType, "/")
+	quality, specificity := 0.0, -1
+
+	for _, part := range strings.Split(accept, ",") {
+		fields := strings.Split(part, ";")
+		mediaRange := strings.ToLower(strings.TrimSpace(fields[0]))
+
+		rangeSpecificity := -1
+		switch mediaRange {
+		case mediaType:
+			rangeSpecificity = 2
+		case mainType + "/*":
+			rangeSpecificity = 1
+		case "*/*":
+			rangeSpecificity = 0
+		}
+		if rangeSpecificity <= specificity {
+			continue
+		}
+
+		q := 1.0
+		for _, param := range fields[1:] {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if parsed, err := strconv.ParseFloat(value, 64); err == nil {
+					q = parsed
+				}
+			}
+		}
+		quality, specificity = q, rangeSpecificity
+	}
+	return quality
+}
@@ -0,0 +1,42 @@
+package api
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateFormat(t *testing.T) {
+	tests := []struct {
+		name        string
+		formatParam string
+		accept      string
+		expected    Format
+		expectedErr error
+	}{
+		{name: "defaults to json", expected: FormatJSON},
+		{name: "format parameter", formatParam: "markdown", accept: "text/html", expected: FormatMarkdown},
+		{name: "unknown format parameter", formatParam: "pdf", expectedErr: ErrUnknownFormat},
+		{name: "exact media type", accept: "text/markdown", expected: FormatMarkdown},
+		{name: "plain text", accept: "text/plain", expected: FormatText},
+		{name: "browser accept header", accept: "text/html,application/xhtml+xml,application/xml;q=0.9,*/*;q=0.8", expected: FormatHTML},
+		{name: "quality values", accept: "text/html;q=0.5, text/markdown;q=0.9", expected: FormatMarkdown},
+		{name: "wildcard", accept: "*/*", expected: FormatJSON},
+		{name: "type wildcard", accept: "text/*", expected: FormatHTML},
+		{name: "excluded media type", accept: "text/*, text/html;q=0", expected: FormatMarkdown},
+		{name: "not acceptable", accept: "image/png", expectedErr: ErrNotAcceptable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, err := negotiateFormat(tt.formatParam, tt.accept)
+
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, format)
+		})
+	}
+}
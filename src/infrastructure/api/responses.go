@@ -0,0 +1,51 @@
+package api
+
+import (
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// Content types
+const (
+	ContentTypeJSON  = "application/json"
+	ContentTypeXML   = "application/xml"
+	ContentTypePlain = "text/plain; charset=utf-8"
+	ContentTypeCSS   = "text/css; charset=utf-8"
+
+	ContentTypeHTML     = "text/html; charset=utf-8"
+	ContentTypeMarkdown = "text/markdown; charset=utf-8"
+)
+
+// createResponse creates an API Gateway response with the given content type
+func createResponse(statusCode int, contentType, body string) events.APIGatewayProxyResponse {
+	return events.APIGatewayProxyResponse{
+		Body:       body,
+		StatusCode: statusCode,
+		Headers: map[string]string{
+			"Content-Type": contentType,
+		},
+	}
+}
+
+// createErrorResponse creates an API Gateway response for error cases
+func createErrorResponse(statusCode int, message string) events.APIGatewayProxyResponse {
+	return createResponse(statusCode, ContentTypeJSON, message)
+}
+
+// headerValue looks up a request header case-insensitively, API Gateway keeps the client's casing
+func headerValue(headers map[string]string, name string) string {
+	for key, value := range headers {
+		if strings.EqualFold(key, name) {
+			return value
+		}
+	}
+	return ""
+}
+
+// createRedirectResponse creates an API Gateway response redirecting to the given location
+func createRedirectResponse(statusCode int, location string) events.APIGatewayProxyResponse {
+	response := createResponse(statusCode, ContentTypePlain, "")
+	response.Headers["Location"] = location
+	return response
+}
@@ -0,0 +1,104 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/aws/aws-lambda-go/events"
+)
+
+// HandlerFunc handles a single API Gateway request
+type HandlerFunc func(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error)
+
+// Router dispatches API Gateway requests to handlers by path and method.
+// Paths may contain parameters like /posts/{anchor}, which are passed to
+// the handler in request.PathParameters.
+type Router struct {
+	routes   map[string]map[string]HandlerFunc
+	patterns []string
+}
+
+// NewRouter creates a new Router instance
+func NewRouter() *Router {
+	return &Router{
+		routes: make(map[string]map[string]HandlerFunc),
+	}
+}
+
+// Handle registers a handler for the given method and path
+func (r *Router) Handle(method, path string, handler HandlerFunc) {
+	if r.routes[path] == nil {
+		r.routes[path] = make(map[string]HandlerFunc)
+		if strings.Contains(path, "{") {
+			r.patterns = append(r.patterns, path)
+		}
+	}
+	r.routes[path][method] = handler
+}
+
+// Route dispatches the request to the matching handler
+func (r *Router) Route(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	// Direct invocations may omit HTTP details, treat them as GET /
+	path := request.Path
+	if path == "" {
+		path = "/"
+	}
+	method := request.HTTPMethod
+	if method == "" {
+		method = http.MethodGet
+	}
+
+	handlers, params, ok := r.match(path)
+	if !ok {
+		return createErrorResponse(http.StatusNotFound, "Not found"), nil
+	}
+
+	handler, ok := handlers[method]
+	if !ok {
+		return createErrorResponse(http.StatusMethodNotAllowed, "Method not allowed"), nil
+	}
+
+	if len(params) > 0 {
+		request.PathParameters = params
+	}
+	return handler(ctx, request)
+}
+
+// match finds the handlers registered for a path, static paths take precedence over patterns
+func (r *Router) match(path string) (map[string]HandlerFunc, map[string]string, bool) {
+	if handlers, ok := r.routes[path]; ok {
+		return handlers, nil, true
+	}
+
+	for _, pattern := range r.patterns {
+		if params, ok := matchPattern(pattern, path); ok {
+			return r.routes[pattern], params, true
+		}
+	}
+	return nil, nil, false
+}
+
+// matchPattern matches a path against a pattern segment by segment and extracts its parameters
+func matchPattern(pattern, path string) (map[string]string, bool) {
+	patternSegments := strings.Split(strings.Trim(pattern, "/"), "/")
+	pathSegments := strings.Split(strings.Trim(path, "/"), "/")
+	if len(patternSegments) != len(pathSegments) {
+		return nil, false
+	}
+
+	params := make(map[string]string)
+	for i, segment := range patternSegments {
+		if strings.HasPrefix(segment, "{") && strings.HasSuffix(segment, "}") {
+			if pathSegments[i] == "" {
+				return nil, false
+			}
+			params[segment[1:len(segment)-1]] = pathSegments[i]
+			continue
+		}
+		if segment != pathSegments[i] {
+			return nil, false
+		}
+	}
+	return params, true
+}
@@ -0,0 +1,91 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+func stubHandler(body string) HandlerFunc {
+	return func(_ context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return createResponse(http.StatusOK, ContentTypePlain, body), nil
+	}
+}
+
+func TestRouter_Route(t *testing.T) {
+	router := NewRouter()
+	router.Handle(http.MethodGet, "/", stubHandler("posts"))
+	router.Handle(http.MethodGet, "/robots.txt", stubHandler("robots"))
+
+	response, err := router.Route(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/robots.txt",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "robots", response.Body)
+}
+
+func TestRouter_Route_DefaultsToRoot(t *testing.T) {
+	router := NewRouter()
+	router.Handle(http.MethodGet, "/", stubHandler("posts"))
+
+	response, err := router.Route(context.Background(), events.APIGatewayProxyRequest{})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "posts", response.Body)
+}
+
+func TestRouter_Route_NotFound(t *testing.T) {
+	router := NewRouter()
+	router.Handle(http.MethodGet, "/", stubHandler("posts"))
+
+	response, err := router.Route(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/missing",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
+
+func TestRouter_Route_MethodNotAllowed(t *testing.T) {
+	router := NewRouter()
+	router.Handle(http.MethodGet, "/", stubHandler("posts"))
+
+	response, err := router.Route(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodPost,
+		Path:       "/",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusMethodNotAllowed, response.StatusCode)
+}
+
+func TestRouter_Route_PathParameters(t *testing.T) {
+	router := NewRouter()
+	router.Handle(http.MethodGet, "/posts/{anchor}", func(_ context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+		return createResponse(http.StatusOK, ContentTypePlain, request.PathParameters["anchor"]), nil
+	})
+
+	response, err := router.Route(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/posts/testing-guideline",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Equal(t, "testing-guideline", response.Body)
+
+	response, err = router.Route(context.Background(), events.APIGatewayProxyRequest{
+		HTTPMethod: http.MethodGet,
+		Path:       "/posts/testing-guideline/extra",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
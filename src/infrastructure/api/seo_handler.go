@@ -0,0 +1,72 @@
+package api
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"buyallmemes.com/blog-api/src/infrastructure/seo"
+	blogUsecase "buyallmemes.com/blog-api/src/usecase/blog"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+)
+
+// SEOHandler serves the documents consumed by search engine crawlers
+type SEOHandler struct {
+	blogService blogUsecase.BlogService
+	sitemap     *seo.SitemapGenerator
+	robots      *seo.RobotsConfig
+	logger      *logging.Logger
+}
+
+// NewSEOHandler creates a new SEOHandler instance
+func NewSEOHandler(
+	blogService blogUsecase.BlogService,
+	sitemap *seo.SitemapGenerator,
+	robots *seo.RobotsConfig,
+	logger *logging.Logger,
+) *SEOHandler {
+	return &SEOHandler{
+		blogService: blogService,
+		sitemap:     sitemap,
+		robots:      robots,
+		logger:      logger,
+	}
+}
+
+// GetSitemap returns the sitemap, or one of its pages when ?page=n is given
+func (h *SEOHandler) GetSitemap(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	page := 0
+	if value, ok := request.QueryStringParameters["page"]; ok {
+		parsed, err := strconv.Atoi(value)
+		if err != nil || parsed < 1 {
+			return createErrorResponse(http.StatusBadRequest, "Invalid sitemap page"), nil
+		}
+		page = parsed
+	}
+
+	blogData, err := h.blogService.GetAllPosts(ctx)
+	if err != nil {
+		h.logger.Error("Error fetching blog posts", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error fetching blog posts"),
+			errors.Wrap(err, "error fetching blog posts")
+	}
+
+	body, err := h.sitemap.Generate(blogData.Posts, page, h.robots.SitemapURL)
+	if errors.Is(err, seo.ErrSitemapPageNotFound) {
+		return createErrorResponse(http.StatusNotFound, "Sitemap page not found"), nil
+	}
+	if err != nil {
+		h.logger.Error("Error generating sitemap", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error generating sitemap"),
+			errors.Wrap(err, "error generating sitemap")
+	}
+
+	return createResponse(http.StatusOK, ContentTypeXML, string(body)), nil
+}
+
+// GetRobots returns robots.txt pointing crawlers to the sitemap
+func (h *SEOHandler) GetRobots(_ context.Context, _ events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	return createResponse(http.StatusOK, ContentTypePlain, seo.GenerateRobots(h.robots)), nil
+}
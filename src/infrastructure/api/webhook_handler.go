@@ -0,0 +1,256 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/pkg/errors"
+)
+
+// Webhook delivery statuses
+const (
+	WebhookRefreshed   = "refreshed"
+	WebhookIgnored     = "ignored"
+	WebhookDuplicate   = "duplicate"
+	WebhookInvalidated = "invalidated"
+	WebhookPong        = "pong"
+)
+
+// maxPushCommits is the number of commits GitHub lists in a push event, larger pushes are truncated
+const maxPushCommits = 2048
+
+// maxDeliveries is the number of delivery IDs remembered to skip redeliveries
+const maxDeliveries = 1000
+
+// WebhookConfig holds the configuration of the GitHub webhook
+type WebhookConfig struct {
+	// Secret is the secret the deliveries are signed with. Without a secret the webhook is disabled.
+	Secret string
+
+	// Repository is the full name of the posts repository, e.g. buyallmemes/blog-api
+	Repository string
+
+	// Ref is the ref the posts are read at, the same value as the Ref of the GitHub repository,
+	// e.g. main or refs/heads/main. Empty uses the default branch.
+	Ref string
+
+	// Path is the path to the blog posts directory in the repository
+	Path string
+}
+
+// webhookResponse is the outcome of a webhook delivery
+type webhookResponse struct {
+	Status string   `json:"status"`
+	Files  []string `json:"files,omitempty"`
+}
+
+// pushEvent is the part of a GitHub push event used to refresh the posts
+type pushEvent struct {
+	Ref     string `json:"ref"`
+	Deleted bool   `json:"deleted"`
+	Forced  bool   `json:"forced"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+	Repository struct {
+		FullName      string `json:"full_name"`
+		DefaultBranch string `json:"default_branch"`
+	} `json:"repository"`
+}
+
+// WebhookHandler refreshes the cached posts on GitHub push events.
+// The processed deliveries and the refreshed posts are kept in memory, per Lambda container: a delivery refreshes
+// the container it reaches, while other warm containers serve their cached posts until the cache TTL expires.
+// Likewise a redelivery reaching another container is processed again.
+type WebhookHandler struct {
+	refresher blog.PostRefresher
+	config    WebhookConfig
+	logger    *logging.Logger
+
+	// deliveries remembers the processed delivery IDs, oldest first
+	mu         sync.Mutex
+	deliveries []string
+}
+
+// NewWebhookHandler creates a new WebhookHandler instance
+func NewWebhookHandler(refresher blog.PostRefresher, config WebhookConfig, logger *logging.Logger) *WebhookHandler {
+	config.Path = strings.Trim(config.Path, "/")
+	// Push events name the full ref, a branch name is a branch of the repository
+	if config.Ref != "" && !strings.HasPrefix(config.Ref, "refs/") {
+		config.Ref = "refs/heads/" + config.Ref
+	}
+	return &WebhookHandler{
+		refresher: refresher,
+		config:    config,
+		logger:    logger,
+	}
+}
+
+// PostGitHub verifies the signature of a GitHub delivery and refreshes the posts changed by push events
+func (h *WebhookHandler) PostGitHub(ctx context.Context, request events.APIGatewayProxyRequest) (events.APIGatewayProxyResponse, error) {
+	if h.config.Secret == "" {
+		return createErrorResponse(http.StatusNotFound, "Not found"), nil
+	}
+
+	body := []byte(request.Body)
+	if request.IsBase64Encoded {
+		decoded, err := base64.StdEncoding.DecodeString(request.Body)
+		if err != nil {
+			return createErrorResponse(http.StatusBadRequest, "Invalid body"), nil
+		}
+		body = decoded
+	}
+
+	if !h.verify(body, headerValue(request.Headers, "X-Hub-Signature-256")) {
+		h.logger.Warn("Rejected webhook delivery with an invalid signature",
+			"delivery", headerValue(request.Headers, "X-GitHub-Delivery"),
+		)
+		return createErrorResponse(http.StatusUnauthorized, "Invalid signature"), nil
+	}
+
+	switch event := headerValue(request.Headers, "X-GitHub-Event"); event {
+	case "ping":
+		return h.respond(webhookResponse{Status: WebhookPong})
+	case "push":
+	default:
+		return h.respond(webhookResponse{Status: WebhookIgnored})
+	}
+
+	delivery := headerValue(request.Headers, "X-GitHub-Delivery")
+	if !h.claim(delivery) {
+		return h.respond(webhookResponse{Status: WebhookDuplicate})
+	}
+
+	var push pushEvent
+	if err := json.Unmarshal(body, &push); err != nil {
+		h.release(delivery)
+		return createErrorResponse(http.StatusBadRequest, "Invalid push event"), nil
+	}
+
+	response, err := h.handlePush(ctx, push)
+	if err != nil {
+		// Release the delivery, so a redelivery refreshes the posts again
+		h.release(delivery)
+		h.logger.Error("Error refreshing posts", "delivery", delivery, "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error refreshing posts"),
+			errors.Wrap(err, "error refreshing posts")
+	}
+
+	h.logger.Info("Processed webhook delivery", "delivery", delivery, "status", response.Status, "files", response.Files)
+	return h.respond(response)
+}
+
+// handlePush refreshes the posts changed by a push to the ref of the posts
+func (h *WebhookHandler) handlePush(ctx context.Context, push pushEvent) (webhookResponse, error) {
+	ref := h.config.Ref
+	if ref == "" {
+		ref = "refs/heads/" + push.Repository.DefaultBranch
+	}
+	if push.Ref != ref || push.Deleted ||
+		(h.config.Repository != "" && !strings.EqualFold(push.Repository.FullName, h.config.Repository)) {
+		return webhookResponse{Status: WebhookIgnored}, nil
+	}
+
+	// Rewritten history and truncated commit lists do not tell every changed file
+	if push.Forced || len(push.Commits) >= maxPushCommits {
+		h.refresher.Invalidate()
+		return webhookResponse{Status: WebhookInvalidated}, nil
+	}
+
+	filenames := h.changedPosts(push)
+	if len(filenames) == 0 {
+		return webhookResponse{Status: WebhookIgnored}, nil
+	}
+
+	if err := h.refresher.RefreshPosts(ctx, filenames); err != nil {
+		return webhookResponse{}, err
+	}
+	return webhookResponse{Status: WebhookRefreshed, Files: filenames}, nil
+}
+
+// changedPosts returns the sorted names of the posts added, modified or removed by the push
+func (h *WebhookHandler) changedPosts(push pushEvent) []string {
+	var filenames []string
+	for _, commit := range push.Commits {
+		for _, files := range [][]string{commit.Added, commit.Modified, commit.Removed} {
+			for _, file := range files {
+				if path.Dir(file) != h.config.Path || !strings.HasSuffix(file, ".md") {
+					continue
+				}
+				if filename := path.Base(file); !slices.Contains(filenames, filename) {
+					filenames = append(filenames, filename)
+				}
+			}
+		}
+	}
+	slices.Sort(filenames)
+	return filenames
+}
+
+// verify checks the HMAC-SHA256 signature of the body, given as sha256=<hex>
+func (h *WebhookHandler) verify(body []byte, signature string) bool {
+	digest, ok := strings.CutPrefix(signature, "sha256=")
+	if !ok {
+		return false
+	}
+	expected, err := hex.DecodeString(digest)
+	if err != nil {
+		return false
+	}
+
+	mac := hmac.New(sha256.New, []byte(h.config.Secret))
+	mac.Write(body)
+	return hmac.Equal(mac.Sum(nil), expected)
+}
+
+// claim records the delivery ID, returning false when the delivery was already processed
+func (h *WebhookHandler) claim(delivery string) bool {
+	if delivery == "" {
+		return true
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if slices.Contains(h.deliveries, delivery) {
+		return false
+	}
+	h.deliveries = append(h.deliveries, delivery)
+	if len(h.deliveries) > maxDeliveries {
+		h.deliveries = h.deliveries[1:]
+	}
+	return true
+}
+
+// release forgets a delivery ID that failed to process
+func (h *WebhookHandler) release(delivery string) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.deliveries = slices.DeleteFunc(h.deliveries, func(id string) bool { return id == delivery })
+}
+
+// respond creates the JSON response of a processed delivery
+func (h *WebhookHandler) respond(response webhookResponse) (events.APIGatewayProxyResponse, error) {
+	body, err := json.Marshal(response)
+	if err != nil {
+		h.logger.Error("Error marshalling webhook response", "error", err)
+		return createErrorResponse(http.StatusInternalServerError, "Error processing webhook"),
+			errors.Wrap(err, "error marshalling webhook response")
+	}
+	return createResponse(http.StatusOK, ContentTypeJSON, string(body)), nil
+}
@@ -0,0 +1,195 @@
+package api
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"net/http"
+	"strings"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubPostRefresher is a stub implementation of the PostRefresher interface
+type StubPostRefresher struct {
+	refreshed   [][]string
+	invalidated int
+	err         error
+}
+
+func (s *StubPostRefresher) RefreshPosts(_ context.Context, filenames []string) error {
+	s.refreshed = append(s.refreshed, filenames)
+	return s.err
+}
+
+func (s *StubPostRefresher) Invalidate() {
+	s.invalidated++
+}
+
+const webhookSecret = "webhook-secret"
+
+const pushBody = `{
+	"ref": "refs/heads/main",
+	"repository": {"full_name": "buyallmemes/blog-api", "default_branch": "main"},
+	"commits": [
+		{"added": ["posts/20240601-new.md"], "modified": ["posts/20240516-hello.md", "README.md"], "removed": []},
+		{"added": ["posts/images/cover.png"], "modified": ["posts/20240516-hello.md"], "removed": ["posts/20240517-old.md"]}
+	]
+}`
+
+// webhookRequest creates a delivery of the event signed with the secret
+func webhookRequest(event, delivery, body, secret string) events.APIGatewayProxyRequest {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(body))
+	return events.APIGatewayProxyRequest{
+		Body: body,
+		Headers: map[string]string{
+			"x-github-event":      event,
+			"x-github-delivery":   delivery,
+			"x-hub-signature-256": "sha256=" + hex.EncodeToString(mac.Sum(nil)),
+		},
+	}
+}
+
+func newTestWebhookHandler(refresher *StubPostRefresher) *WebhookHandler {
+	return NewWebhookHandler(refresher, WebhookConfig{
+		Secret:     webhookSecret,
+		Repository: "buyallmemes/blog-api",
+		Path:       "posts",
+	}, logging.Default())
+}
+
+func TestWebhookHandler_PostGitHub_Push(t *testing.T) {
+	refresher := &StubPostRefresher{}
+	handler := newTestWebhookHandler(refresher)
+
+	response, err := handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, webhookSecret))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.JSONEq(t, `{"status":"refreshed","files":["20240516-hello.md","20240517-old.md","20240601-new.md"]}`, response.Body)
+	assert.Equal(t, [][]string{{"20240516-hello.md", "20240517-old.md", "20240601-new.md"}}, refresher.refreshed)
+
+	// Redeliveries are processed only once
+	response, err = handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, webhookSecret))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":"duplicate"}`, response.Body)
+	assert.Len(t, refresher.refreshed, 1)
+}
+
+func TestWebhookHandler_PostGitHub_InvalidSignature(t *testing.T) {
+	refresher := &StubPostRefresher{}
+	handler := newTestWebhookHandler(refresher)
+
+	response, err := handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, "wrong-secret"))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	request := webhookRequest("push", "d1", pushBody, webhookSecret)
+	delete(request.Headers, "x-hub-signature-256")
+	response, err = handler.PostGitHub(context.Background(), request)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusUnauthorized, response.StatusCode)
+
+	assert.Empty(t, refresher.refreshed)
+}
+
+func TestWebhookHandler_PostGitHub_Ignored(t *testing.T) {
+	tests := []struct {
+		name   string
+		event  string
+		body   string
+		status string
+	}{
+		{name: "ping", event: "ping", body: `{}`, status: WebhookPong},
+		{name: "other event", event: "issues", body: `{}`, status: WebhookIgnored},
+		{
+			name:   "other branch",
+			event:  "push",
+			body:   `{"ref": "refs/heads/draft", "repository": {"full_name": "buyallmemes/blog-api", "default_branch": "main"}, "commits": [{"added": ["posts/a.md"]}]}`,
+			status: WebhookIgnored,
+		},
+		{
+			name:   "other repository",
+			event:  "push",
+			body:   `{"ref": "refs/heads/main", "repository": {"full_name": "someone/else", "default_branch": "main"}, "commits": [{"added": ["posts/a.md"]}]}`,
+			status: WebhookIgnored,
+		},
+		{
+			name:   "no posts changed",
+			event:  "push",
+			body:   `{"ref": "refs/heads/main", "repository": {"full_name": "buyallmemes/blog-api", "default_branch": "main"}, "commits": [{"modified": ["main.go"]}]}`,
+			status: WebhookIgnored,
+		},
+		{
+			name:   "force push",
+			event:  "push",
+			body:   `{"ref": "refs/heads/main", "forced": true, "repository": {"full_name": "buyallmemes/blog-api", "default_branch": "main"}, "commits": []}`,
+			status: WebhookInvalidated,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refresher := &StubPostRefresher{}
+
+			response, err := newTestWebhookHandler(refresher).PostGitHub(context.Background(), webhookRequest(tt.event, "d1", tt.body, webhookSecret))
+
+			assert.NoError(t, err)
+			assert.Equal(t, http.StatusOK, response.StatusCode)
+			assert.JSONEq(t, `{"status":"`+tt.status+`"}`, response.Body)
+			assert.Empty(t, refresher.refreshed)
+			assert.Equal(t, tt.status == WebhookInvalidated, refresher.invalidated == 1)
+		})
+	}
+}
+
+func TestWebhookHandler_PostGitHub_Ref(t *testing.T) {
+	refresher := &StubPostRefresher{}
+	// The ref of the posts may be a branch name, like the ref of the GitHub repository
+	handler := NewWebhookHandler(refresher, WebhookConfig{
+		Secret:     webhookSecret,
+		Repository: "buyallmemes/blog-api",
+		Ref:        "drafts",
+		Path:       "posts",
+	}, logging.Default())
+
+	response, err := handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, webhookSecret))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":"ignored"}`, response.Body)
+
+	body := strings.Replace(pushBody, `"ref": "refs/heads/main"`, `"ref": "refs/heads/drafts"`, 1)
+	response, err = handler.PostGitHub(context.Background(), webhookRequest("push", "d2", body, webhookSecret))
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"status":"refreshed","files":["20240516-hello.md","20240517-old.md","20240601-new.md"]}`, response.Body)
+}
+
+func TestWebhookHandler_PostGitHub_RefreshFailure(t *testing.T) {
+	refresher := &StubPostRefresher{err: errors.New("rate limit exceeded")}
+	handler := newTestWebhookHandler(refresher)
+
+	response, err := handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, webhookSecret))
+	assert.Error(t, err)
+	assert.Equal(t, http.StatusInternalServerError, response.StatusCode)
+
+	// A failed delivery may be redelivered
+	refresher.err = nil
+	response, err = handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, webhookSecret))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, response.StatusCode)
+	assert.Len(t, refresher.refreshed, 2)
+}
+
+func TestWebhookHandler_PostGitHub_Disabled(t *testing.T) {
+	handler := NewWebhookHandler(&StubPostRefresher{}, WebhookConfig{}, logging.Default())
+
+	response, err := handler.PostGitHub(context.Background(), webhookRequest("push", "d1", pushBody, ""))
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, response.StatusCode)
+}
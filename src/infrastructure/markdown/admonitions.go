@@ -0,0 +1,221 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ExtensionAdmonitions renders > [!NOTE] alerts and :::warning containers as callout boxes
+const ExtensionAdmonitions Extension = "admonitions"
+
+// KindAdmonition is the node kind of callout blocks
+var KindAdmonition = ast.NewNodeKind("Admonition")
+
+// alertPattern matches the marker line of a GitHub-style alert, e.g. [!NOTE]
+var alertPattern = regexp.MustCompile(`^\[!([A-Za-z]+)\]$`)
+
+// containerPattern matches the opening line of a container block, e.g. :::warning Optional title
+var containerPattern = regexp.MustCompile(`^:::\s*([A-Za-z]+)\s*(.*)$`)
+
+// DefaultAdmonitionTypes returns the callout types supported by default, matching GitHub alerts
+func DefaultAdmonitionTypes() []string {
+	return []string{"note", "tip", "important", "warning", "caution"}
+}
+
+// WithAdmonitionTypes sets the supported callout types
+func WithAdmonitionTypes(types ...string) Option {
+	return func(p *GoldmarkParser) {
+		p.admonitionTypes = types
+	}
+}
+
+// Admonition is a callout block such as a note or a warning
+type Admonition struct {
+	ast.BaseBlock
+	AdmonitionType string
+	Title          string
+}
+
+// Kind implements ast.Node
+func (n *Admonition) Kind() ast.NodeKind {
+	return KindAdmonition
+}
+
+// Dump implements ast.Node
+func (n *Admonition) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Type": n.AdmonitionType, "Title": n.Title}, nil)
+}
+
+// newAdmonition creates a callout of the given type, titled after the type unless a title is given
+func newAdmonition(admonitionType, title string) *Admonition {
+	if title == "" {
+		title = strings.ToUpper(admonitionType[:1]) + admonitionType[1:]
+	}
+	return &Admonition{AdmonitionType: admonitionType, Title: title}
+}
+
+// admonitionExtension registers the callout parsers and renderer
+type admonitionExtension struct {
+	types map[string]bool
+}
+
+// newAdmonitionExtension creates the extension for the given callout types
+func newAdmonitionExtension(types []string) *admonitionExtension {
+	e := &admonitionExtension{types: make(map[string]bool, len(types))}
+	for _, t := range types {
+		e.types[strings.ToLower(t)] = true
+	}
+	return e
+}
+
+// Extend implements goldmark.Extender
+func (e *admonitionExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&containerParser{types: e.types}, 95)),
+		parser.WithASTTransformers(util.Prioritized(&alertTransformer{types: e.types}, 100)),
+	)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(util.Prioritized(&admonitionRenderer{}, 100)),
+	)
+}
+
+// containerParser parses :::type container blocks, closed by a ::: line
+type containerParser struct {
+	types map[string]bool
+}
+
+// Trigger implements parser.BlockParser
+func (p *containerParser) Trigger() []byte {
+	return []byte{':'}
+}
+
+// Open implements parser.BlockParser
+func (p *containerParser) Open(_ ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 {
+		return nil, parser.NoChildren
+	}
+
+	match := containerPattern.FindSubmatch(bytes.TrimSpace(line[pos:]))
+	if match == nil || !p.types[strings.ToLower(string(match[1]))] {
+		return nil, parser.NoChildren
+	}
+
+	reader.Advance(segment.Len() - 1)
+	return newAdmonition(strings.ToLower(string(match[1])), string(match[2])), parser.HasChildren
+}
+
+// Continue implements parser.BlockParser
+func (p *containerParser) Continue(_ ast.Node, reader text.Reader, _ parser.Context) parser.State {
+	line, segment := reader.PeekLine()
+	if bytes.Equal(bytes.TrimSpace(line), []byte(":::")) {
+		reader.Advance(segment.Len() - 1)
+		return parser.Close
+	}
+	return parser.Continue | parser.HasChildren
+}
+
+// Close implements parser.BlockParser
+func (p *containerParser) Close(ast.Node, text.Reader, parser.Context) {}
+
+// CanInterruptParagraph implements parser.BlockParser
+func (p *containerParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser
+func (p *containerParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// alertTransformer turns blockquotes starting with a [!TYPE] line into callouts
+type alertTransformer struct {
+	types map[string]bool
+}
+
+// Transform implements parser.ASTTransformer
+func (t *alertTransformer) Transform(doc *ast.Document, reader text.Reader, _ parser.Context) {
+	source := reader.Source()
+
+	var quotes []*ast.Blockquote
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if quote, ok := node.(*ast.Blockquote); ok && entering {
+			quotes = append(quotes, quote)
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, quote := range quotes {
+		paragraph, ok := quote.FirstChild().(*ast.Paragraph)
+		if !ok || paragraph.Lines().Len() == 0 {
+			continue
+		}
+
+		firstLine := paragraph.Lines().At(0)
+		marker := bytes.TrimSpace(firstLine.Value(source))
+		match := alertPattern.FindSubmatch(marker)
+		if match == nil || !t.types[strings.ToLower(string(match[1]))] {
+			continue
+		}
+
+		removeFirstLine(paragraph)
+		if paragraph.ChildCount() == 0 {
+			quote.RemoveChild(quote, paragraph)
+		}
+
+		admonition := newAdmonition(strings.ToLower(string(match[1])), "")
+		for child := quote.FirstChild(); child != nil; child = quote.FirstChild() {
+			admonition.AppendChild(admonition, child)
+		}
+		quote.Parent().ReplaceChild(quote.Parent(), quote, admonition)
+	}
+}
+
+// removeFirstLine removes the first line of a paragraph along with its inline nodes
+func removeFirstLine(paragraph *ast.Paragraph) {
+	firstLine := paragraph.Lines().At(0)
+	paragraph.Lines().SetSliced(1, paragraph.Lines().Len())
+
+	for child := paragraph.FirstChild(); child != nil; child = paragraph.FirstChild() {
+		textNode, ok := child.(*ast.Text)
+		if !ok || textNode.Segment.Start >= firstLine.Stop {
+			break
+		}
+		paragraph.RemoveChild(paragraph, child)
+		if textNode.SoftLineBreak() || textNode.HardLineBreak() {
+			break
+		}
+	}
+}
+
+// admonitionRenderer renders callouts as <aside> elements with stable class names
+type admonitionRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer
+func (r *admonitionRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindAdmonition, r.render)
+}
+
+// render renders a callout
+func (r *admonitionRenderer) render(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	n := node.(*Admonition)
+	if !entering {
+		_, _ = w.WriteString("</aside>\n")
+		return ast.WalkContinue, nil
+	}
+
+	_, _ = fmt.Fprintf(w, "<aside class=\"admonition admonition-%s\" role=\"note\">\n", n.AdmonitionType)
+	_, _ = fmt.Fprintf(w, "<p class=\"admonition-title\">%s</p>\n", html.EscapeString(n.Title))
+	return ast.WalkContinue, nil
+}
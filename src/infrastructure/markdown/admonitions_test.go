@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldmarkParser_ParseMarkdown_Alert(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("> [!NOTE]\n> Useful information that users should know.\n>\n> Second paragraph.\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<aside class=\"admonition admonition-note\" role=\"note\">\n"+
+		"<p class=\"admonition-title\">Note</p>\n"+
+		"<p>Useful information that users should know.</p>\n"+
+		"<p>Second paragraph.</p>\n"+
+		"</aside>\n", parsed.Content)
+	assert.Equal(t, 8, parsed.WordCount)
+}
+
+func TestGoldmarkParser_ParseMarkdown_AlertMarkerOnly(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("> [!TIP]\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<aside class=\"admonition admonition-tip\" role=\"note\">\n"+
+		"<p class=\"admonition-title\">Tip</p>\n"+
+		"</aside>\n", parsed.Content)
+}
+
+func TestGoldmarkParser_ParseMarkdown_UnknownAlertStaysBlockquote(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("> [!RANT]\n> Frontend is hard.\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<blockquote>")
+	assert.NotContains(t, parsed.Content, "admonition")
+}
+
+func TestGoldmarkParser_ParseMarkdown_Container(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `Before
+
+:::warning Mind the cycle
+Circular dependencies **hurt**.
+
+- one
+- two
+:::
+
+After
+`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>Before</p>\n"+
+		"<aside class=\"admonition admonition-warning\" role=\"note\">\n"+
+		"<p class=\"admonition-title\">Mind the cycle</p>\n"+
+		"<p>Circular dependencies <strong>hurt</strong>.</p>\n"+
+		"<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"+
+		"</aside>\n"+
+		"<p>After</p>\n", parsed.Content)
+}
+
+func TestGoldmarkParser_ParseMarkdown_CustomAdmonitionTypes(t *testing.T) {
+	parser := NewGoldmarkParser(WithAdmonitionTypes("info"))
+
+	parsed, err := parser.ParseMarkdown(":::info\nHeads up.\n:::\n\n:::warning\nIgnored.\n:::\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<aside class="admonition admonition-info" role="note">`)
+	assert.NotContains(t, parsed.Content, "admonition-warning")
+	assert.Contains(t, parsed.Content, ":::warning")
+}
@@ -0,0 +1,234 @@
+package markdown
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"html"
+	"strings"
+	"sync"
+
+	"github.com/microcosm-cc/bluemonday"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ExtensionDiagrams renders ```mermaid and ```plantuml fenced blocks as inline SVG
+const ExtensionDiagrams Extension = "diagrams"
+
+// Diagram languages
+const (
+	DiagramMermaid  = "mermaid"
+	DiagramPlantUML = "plantuml"
+)
+
+// KindDiagram is the node kind of diagram blocks
+var KindDiagram = ast.NewNodeKind("Diagram")
+
+// DiagramRenderer renders diagram source code to SVG
+type DiagramRenderer interface {
+	// Render renders the source of a diagram in the given language to an SVG document
+	Render(ctx context.Context, language, source string) (string, error)
+}
+
+// Diagram is a fenced diagram block, rendered to SVG at parse time.
+// The block renders to a placeholder which is replaced by the SVG after sanitization,
+// as the SVG is sanitized by its own policy keeping the stylesheet and labels of the diagram.
+type Diagram struct {
+	ast.BaseBlock
+	Language    string
+	Source      string
+	SVG         string
+	Placeholder string
+}
+
+// Kind implements ast.Node
+func (n *Diagram) Kind() ast.NodeKind {
+	return KindDiagram
+}
+
+// IsRaw implements ast.Node
+func (n *Diagram) IsRaw() bool {
+	return true
+}
+
+// Dump implements ast.Node
+func (n *Diagram) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Language": n.Language}, nil)
+}
+
+// WithDiagramRenderer sets the renderer used for diagram blocks.
+// Without a renderer, diagrams are passed through as <pre class="mermaid"> for client-side rendering.
+func WithDiagramRenderer(diagramRenderer DiagramRenderer) Option {
+	return func(p *GoldmarkParser) {
+		p.diagramRenderer = diagramRenderer
+	}
+}
+
+// diagramExtension registers the diagram transformer and renderer
+type diagramExtension struct {
+	renderer  DiagramRenderer
+	sanitizer *bluemonday.Policy
+}
+
+// Extend implements goldmark.Extender
+func (e *diagramExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithASTTransformers(util.Prioritized(&diagramTransformer{renderer: e.renderer, sanitizer: e.sanitizer}, 100)),
+	)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(util.Prioritized(&diagramNodeRenderer{}, 100)),
+	)
+}
+
+// expansionsKey stores the HTML inserted into a document after sanitization by placeholder
+var expansionsKey = parser.NewContextKey()
+
+// expansions maps the placeholders of a document to the HTML replacing them after sanitization
+type expansions struct {
+	nonce string
+	html  map[string]string
+}
+
+// addExpansion stores HTML inserted into the sanitized document and returns the placeholder it replaces.
+// A random nonce keeps placeholders from being forged in the document itself.
+func addExpansion(pc parser.Context, prefix, html string) string {
+	e, ok := pc.Get(expansionsKey).(*expansions)
+	if !ok {
+		nonce := make([]byte, 8)
+		_, _ = rand.Read(nonce)
+		e = &expansions{nonce: hex.EncodeToString(nonce), html: make(map[string]string)}
+		pc.Set(expansionsKey, e)
+	}
+
+	placeholder := fmt.Sprintf("%s-%s-%d", prefix, e.nonce, len(e.html))
+	e.html[placeholder] = strings.TrimRight(html, "\n")
+	return placeholder
+}
+
+// expandPlaceholders replaces the placeholders of sanitized HTML with the HTML they stand for
+func expandPlaceholders(pc parser.Context, content string) string {
+	e, ok := pc.Get(expansionsKey).(*expansions)
+	if !ok || len(e.html) == 0 {
+		return content
+	}
+
+	replacements := make([]string, 0, 2*len(e.html))
+	for placeholder, html := range e.html {
+		replacements = append(replacements, placeholder, html)
+	}
+	return strings.NewReplacer(replacements...).Replace(content)
+}
+
+// diagramTransformer replaces diagram fenced code blocks with rendered Diagram nodes
+type diagramTransformer struct {
+	renderer  DiagramRenderer
+	sanitizer *bluemonday.Policy
+}
+
+// Transform implements parser.ASTTransformer
+func (t *diagramTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	parsed := contextDocument(pc)
+
+	var blocks []*ast.FencedCodeBlock
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if block, ok := node.(*ast.FencedCodeBlock); ok && entering {
+			switch string(block.Language(source)) {
+			case DiagramMermaid, DiagramPlantUML:
+				blocks = append(blocks, block)
+			}
+			return ast.WalkSkipChildren, nil
+		}
+		return ast.WalkContinue, nil
+	})
+
+	for _, block := range blocks {
+		diagram := &Diagram{
+			Language: string(block.Language(source)),
+			Source:   string(block.Lines().Value(source)),
+		}
+
+		if t.renderer != nil {
+			svg, err := t.renderer.Render(parsed.ctx, diagram.Language, diagram.Source)
+			if err != nil {
+				addWarning(pc, source, block.Info.Segment.Start, "%s diagram not rendered: %v", diagram.Language, err)
+			} else {
+				diagram.SVG = sanitizeDiagram(t.sanitizer, svg)
+				diagram.Placeholder = addExpansion(pc, "diagram",
+					fmt.Sprintf("<figure class=\"diagram diagram-%s\">%s</figure>", diagram.Language, diagram.SVG))
+			}
+		}
+
+		block.Parent().ReplaceChild(block.Parent(), block, diagram)
+	}
+}
+
+// diagramNodeRenderer writes the placeholder of the inline SVG, or the diagram source as a passthrough block
+type diagramNodeRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer
+func (r *diagramNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindDiagram, r.render)
+}
+
+// render renders a diagram block
+func (r *diagramNodeRenderer) render(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*Diagram)
+	if n.Placeholder != "" {
+		_, _ = w.WriteString(n.Placeholder + "\n")
+		return ast.WalkSkipChildren, nil
+	}
+
+	_, _ = fmt.Fprintf(w, "<pre class=\"%s\">%s</pre>\n", n.Language, html.EscapeString(strings.TrimRight(n.Source, "\n")))
+	return ast.WalkSkipChildren, nil
+}
+
+// CachedDiagramRenderer caches rendered diagrams by the hash of their language and source
+type CachedDiagramRenderer struct {
+	renderer DiagramRenderer
+	mu       sync.RWMutex
+	cache    map[string]string
+}
+
+// NewCachedDiagramRenderer creates a new CachedDiagramRenderer wrapping the given renderer
+func NewCachedDiagramRenderer(renderer DiagramRenderer) *CachedDiagramRenderer {
+	return &CachedDiagramRenderer{
+		renderer: renderer,
+		cache:    make(map[string]string),
+	}
+}
+
+// Render returns the cached SVG, rendering and caching it on a miss. Failures are not cached.
+func (r *CachedDiagramRenderer) Render(ctx context.Context, language, source string) (string, error) {
+	hash := sha256.Sum256([]byte(language + "\x00" + source))
+	key := hex.EncodeToString(hash[:])
+
+	r.mu.RLock()
+	svg, ok := r.cache[key]
+	r.mu.RUnlock()
+	if ok {
+		return svg, nil
+	}
+
+	svg, err := r.renderer.Render(ctx, language, source)
+	if err != nil {
+		return "", err
+	}
+
+	r.mu.Lock()
+	r.cache[key] = svg
+	r.mu.Unlock()
+
+	return svg, nil
+}
@@ -0,0 +1,147 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubDiagramRenderer is a stub implementation of the DiagramRenderer interface
+type StubDiagramRenderer struct {
+	calls int
+	svg   string
+	err   error
+}
+
+func (s *StubDiagramRenderer) Render(ctx context.Context, language, source string) (string, error) {
+	s.calls++
+	if err := ctx.Err(); err != nil {
+		return "", err
+	}
+	if s.err != nil {
+		return "", s.err
+	}
+	if s.svg != "" {
+		return s.svg, nil
+	}
+	return `<svg aria-roledescription="` + language + `"><g><text>` + source + `</text></g></svg>`, nil
+}
+
+const mermaidDiagram = "```mermaid\ngraph TD;\n  A-->B;\n```\n"
+
+func TestGoldmarkParser_ParseMarkdown_DiagramRendered(t *testing.T) {
+	renderer := &StubDiagramRenderer{}
+	parser := NewGoldmarkParser(WithDiagramRenderer(renderer))
+
+	parsed, err := parser.ParseMarkdown(mermaidDiagram + "\n```plantuml\n@startuml\nA -> B\n@enduml\n```\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<figure class=\"diagram diagram-mermaid\"><svg aria-roledescription=\"mermaid\"><g><text>graph TD;\n  A--&gt;B;\n</text></g></svg></figure>")
+	assert.Contains(t, parsed.Content, `<figure class="diagram diagram-plantuml"><svg aria-roledescription="plantuml">`)
+	assert.Equal(t, 0, parsed.CodeBlockCount)
+	assert.Equal(t, 2, renderer.calls)
+}
+
+func TestGoldmarkParser_ParseMarkdown_DiagramMermaidSVG(t *testing.T) {
+	// SVG of "graph TD; A[Start]-->B[End]" as rendered by Kroki with mermaid 10
+	svg, err := os.ReadFile("testdata/diagrams/mermaid.svg")
+	assert.NoError(t, err)
+	parser := NewGoldmarkParser(WithDiagramRenderer(&StubDiagramRenderer{svg: string(svg)}))
+
+	parsed, err := parser.ParseMarkdown(mermaidDiagram)
+
+	// The stylesheet and HTML labels of the diagram are kept under the strict trust level
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<figure class="diagram diagram-mermaid"><svg aria-roledescription="flowchart-v2"`)
+	assert.Contains(t, parsed.Content, `id="mermaid-svg-1"><style>#mermaid-svg-1{font-family:"trebuchet ms"`)
+	// Browsers restore the case of SVG element and attribute names lowercased by the sanitizer
+	assert.Contains(t, parsed.Content, `<foreignobject height="19" width="39.5"><div xmlns="http://www.w3.org/1999/xhtml" style="display: inline-block; white-space: nowrap"><span class="nodeLabel">Start</span></div></foreignobject>`)
+	assert.Contains(t, parsed.Content, `<span class="nodeLabel">End</span>`)
+	assert.Contains(t, parsed.Content, `marker-end="url(#mermaid-svg-1_flowchart-pointEnd)"`)
+	assert.NotContains(t, parsed.Content, "data-node")
+}
+
+func TestGoldmarkParser_ParseMarkdown_DiagramUnsafeSVG(t *testing.T) {
+	svg := `<svg><style>#d{fill:red}</style><style>a{}</style><img src=x onerror=alert(1)><style>b{}</style>` +
+		`<style>@import "https://evil.example/x.css";</style><script>alert(1)</script>` +
+		`<g onclick="alert(1)"><foreignObject><div style="position: fixed; background: url(https://evil.example)">` +
+		`<iframe src="https://evil.example"></iframe>Label</div></foreignObject></g></svg>`
+	parser := NewGoldmarkParser(WithDiagramRenderer(&StubDiagramRenderer{svg: svg}))
+
+	parsed, err := parser.ParseMarkdown(mermaidDiagram)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<style>#d{fill:red}</style>`)
+	assert.Contains(t, parsed.Content, `<foreignobject><div>Label</div></foreignobject>`)
+	assert.NotContains(t, parsed.Content, "onerror")
+	assert.NotContains(t, parsed.Content, "@import")
+	assert.NotContains(t, parsed.Content, "<script")
+	assert.NotContains(t, parsed.Content, "onclick")
+	assert.NotContains(t, parsed.Content, "evil.example")
+}
+
+func TestGoldmarkParser_ParseDocument_DiagramContext(t *testing.T) {
+	renderer := &StubDiagramRenderer{}
+	parser := NewGoldmarkParser(WithDiagramRenderer(renderer))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	parsed, err := parser.ParseDocument(ctx, blog.Document{Content: mermaidDiagram})
+
+	// Diagrams not rendered before the deadline of the load are passed through
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<pre class="mermaid">`)
+	assert.Equal(t, []string{"line 1: mermaid diagram not rendered: context canceled"}, parsed.Warnings)
+}
+
+func TestGoldmarkParser_ParseMarkdown_DiagramPassthrough(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown(mermaidDiagram)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<pre class=\"mermaid\">graph TD;\n  A--&gt;B;</pre>\n", parsed.Content)
+	assert.Empty(t, parsed.Warnings)
+}
+
+func TestGoldmarkParser_ParseMarkdown_DiagramRenderingFailure(t *testing.T) {
+	parser := NewGoldmarkParser(WithDiagramRenderer(&StubDiagramRenderer{err: errors.New("syntax error")}))
+
+	parsed, err := parser.ParseMarkdown("Intro\n\n" + mermaidDiagram)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<pre class="mermaid">`)
+	assert.Equal(t, []string{"line 3: mermaid diagram not rendered: syntax error"}, parsed.Warnings)
+}
+
+func TestCachedDiagramRenderer_Render(t *testing.T) {
+	stub := &StubDiagramRenderer{}
+	renderer := NewCachedDiagramRenderer(stub)
+
+	first, err := renderer.Render(context.Background(), DiagramMermaid, "graph TD;")
+	assert.NoError(t, err)
+	second, err := renderer.Render(context.Background(), DiagramMermaid, "graph TD;")
+	assert.NoError(t, err)
+	_, err = renderer.Render(context.Background(), DiagramPlantUML, "graph TD;")
+	assert.NoError(t, err)
+
+	assert.Equal(t, first, second)
+	assert.Equal(t, 2, stub.calls)
+}
+
+func TestCachedDiagramRenderer_RenderDoesNotCacheFailures(t *testing.T) {
+	stub := &StubDiagramRenderer{err: errors.New("unavailable")}
+	renderer := NewCachedDiagramRenderer(stub)
+
+	_, err := renderer.Render(context.Background(), DiagramMermaid, "graph TD;")
+	assert.Error(t, err)
+
+	stub.err = nil
+	_, err = renderer.Render(context.Background(), DiagramMermaid, "graph TD;")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stub.calls)
+}
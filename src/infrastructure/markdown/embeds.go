@@ -0,0 +1,79 @@
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+)
+
+// Patterns of the identifiers accepted by the embed shortcodes
+var (
+	youtubeIDPattern   = regexp.MustCompile(`^[A-Za-z0-9_-]{11}$`)
+	gistUserPattern    = regexp.MustCompile(`^[A-Za-z0-9-]+$`)
+	gistIDPattern      = regexp.MustCompile(`^[a-f0-9]+$`)
+	tweetUserPattern   = regexp.MustCompile(`^[A-Za-z0-9_]{1,15}$`)
+	tweetStatusPattern = regexp.MustCompile(`^[0-9]+$`)
+)
+
+// YouTubeShortcode embeds a video: {{< youtube id >}}
+type YouTubeShortcode struct{}
+
+// Name implements Shortcode
+func (YouTubeShortcode) Name() string {
+	return "youtube"
+}
+
+// Render implements Shortcode
+func (YouTubeShortcode) Render(_ context.Context, call ShortcodeCall) (string, error) {
+	id := call.Arg(0)
+	if !youtubeIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid video id %q", id)
+	}
+
+	return fmt.Sprintf(
+		`<figure class="embed embed-youtube"><iframe src="https://www.youtube-nocookie.com/embed/%s" title="YouTube video" loading="lazy" allowfullscreen></iframe></figure>`,
+		id,
+	), nil
+}
+
+// GistShortcode links a gist for client-side embedding: {{< gist user id >}}
+type GistShortcode struct{}
+
+// Name implements Shortcode
+func (GistShortcode) Name() string {
+	return "gist"
+}
+
+// Render implements Shortcode
+func (GistShortcode) Render(_ context.Context, call ShortcodeCall) (string, error) {
+	user, id := call.Arg(0), call.Arg(1)
+	if !gistUserPattern.MatchString(user) || !gistIDPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid gist %q %q", user, id)
+	}
+
+	return fmt.Sprintf(
+		`<figure class="embed embed-gist"><a href="https://gist.github.com/%[1]s/%[2]s">gist.github.com/%[1]s/%[2]s</a></figure>`,
+		user, id,
+	), nil
+}
+
+// TweetShortcode renders the blockquote enhanced by the Twitter widget script: {{< tweet user id >}}
+type TweetShortcode struct{}
+
+// Name implements Shortcode
+func (TweetShortcode) Name() string {
+	return "tweet"
+}
+
+// Render implements Shortcode
+func (TweetShortcode) Render(_ context.Context, call ShortcodeCall) (string, error) {
+	user, id := call.Arg(0), call.Arg(1)
+	if !tweetUserPattern.MatchString(user) || !tweetStatusPattern.MatchString(id) {
+		return "", fmt.Errorf("invalid tweet %q %q", user, id)
+	}
+
+	return fmt.Sprintf(
+		`<blockquote class="embed embed-tweet twitter-tweet"><a href="https://twitter.com/%[1]s/status/%[2]s">Post by @%[1]s</a></blockquote>`,
+		user, id,
+	), nil
+}
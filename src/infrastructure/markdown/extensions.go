@@ -0,0 +1,85 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	emoji "github.com/yuin/goldmark-emoji"
+	"github.com/yuin/goldmark/extension"
+)
+
+// Extension names an optional markdown syntax extension
+type Extension string
+
+// Supported extensions
+const (
+	// ExtensionGFM enables GitHub Flavored Markdown: tables, strikethrough, task lists and autolinks
+	ExtensionGFM Extension = "gfm"
+	// ExtensionFootnotes enables footnote references and definitions
+	ExtensionFootnotes Extension = "footnotes"
+	// ExtensionDefinitionLists enables PHP Markdown Extra definition lists
+	ExtensionDefinitionLists Extension = "definition-lists"
+	// ExtensionTypographer replaces quotes, dashes and ellipses with typographic entities
+	ExtensionTypographer Extension = "typographer"
+	// ExtensionEmoji renders :shortcode: emoji
+	ExtensionEmoji Extension = "emoji"
+)
+
+// ErrUnknownExtension is returned when an extension name is not supported
+var ErrUnknownExtension = errors.New("unknown markdown extension")
+
+// extenders maps each supported extension to its goldmark implementation
+var extenders = map[Extension]func(p *GoldmarkParser) goldmark.Extender{
+	ExtensionGFM:             func(*GoldmarkParser) goldmark.Extender { return extension.GFM },
+	ExtensionFootnotes:       func(*GoldmarkParser) goldmark.Extender { return extension.Footnote },
+	ExtensionDefinitionLists: func(*GoldmarkParser) goldmark.Extender { return extension.DefinitionList },
+	ExtensionTypographer:     func(*GoldmarkParser) goldmark.Extender { return extension.Typographer },
+	ExtensionEmoji:           func(*GoldmarkParser) goldmark.Extender { return emoji.Emoji },
+	ExtensionMath:            func(*GoldmarkParser) goldmark.Extender { return &mathExtension{} },
+	ExtensionDiagrams: func(p *GoldmarkParser) goldmark.Extender {
+		return &diagramExtension{renderer: p.diagramRenderer, sanitizer: p.diagramSanitizer}
+	},
+	ExtensionAdmonitions: func(p *GoldmarkParser) goldmark.Extender {
+		return newAdmonitionExtension(p.admonitionTypes)
+	},
+	ExtensionShortcodes: func(p *GoldmarkParser) goldmark.Extender {
+		return &shortcodeExtension{shortcodes: p.shortcodes}
+	},
+}
+
+// DefaultExtensions returns the extensions enabled by default
+func DefaultExtensions() []Extension {
+	return []Extension{
+		ExtensionGFM,
+		ExtensionFootnotes,
+		ExtensionDefinitionLists,
+		ExtensionTypographer,
+		ExtensionEmoji,
+		ExtensionMath,
+		ExtensionDiagrams,
+		ExtensionAdmonitions,
+		ExtensionShortcodes,
+	}
+}
+
+// ParseExtensions converts extension names into extensions
+func ParseExtensions(names []string) ([]Extension, error) {
+	extensions := make([]Extension, 0, len(names))
+	for _, name := range names {
+		ext := Extension(strings.ToLower(strings.TrimSpace(name)))
+		if _, ok := extenders[ext]; !ok {
+			return nil, fmt.Errorf("%w: %s", ErrUnknownExtension, name)
+		}
+		extensions = append(extensions, ext)
+	}
+	return extensions, nil
+}
+
+// WithExtensions replaces the set of enabled syntax extensions
+func WithExtensions(extensions ...Extension) Option {
+	return func(p *GoldmarkParser) {
+		p.syntaxExtensions = extensions
+	}
+}
@@ -0,0 +1,81 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldmarkParser_ParseMarkdown_GFM(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `| Layer | Package |
+|-------|---------|
+| Domain | blog |
+
+~~deprecated~~
+
+- [x] done
+- [ ] todo
+
+Visit https://buyallmemes.com today.
+`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<table>")
+	assert.Contains(t, parsed.Content, "<td>Domain</td>")
+	assert.Contains(t, parsed.Content, "<del>deprecated</del>")
+	assert.Contains(t, parsed.Content, `<input checked="" disabled="" type="checkbox"`)
+	assert.Contains(t, parsed.Content, `<a href="https://buyallmemes.com" rel="nofollow">https://buyallmemes.com</a>`)
+}
+
+func TestGoldmarkParser_ParseMarkdown_Footnotes(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("Uncle Bob said so[^1].\n\n[^1]: Clean Architecture, 2017.\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<sup id="fnref:1">`)
+	assert.Contains(t, parsed.Content, `<div class="footnotes" role="doc-endnotes">`)
+}
+
+func TestGoldmarkParser_ParseMarkdown_DefinitionLists(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("DIP\n:   Dependency Inversion Principle\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<dl>\n<dt>DIP</dt>\n<dd>Dependency Inversion Principle</dd>\n</dl>")
+}
+
+func TestGoldmarkParser_ParseMarkdown_TypographerAndEmoji(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("# Let's build :rocket:\n\nWait for it...\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "Let’s build 🚀")
+	assert.Contains(t, parsed.Content, "Wait for it…")
+	assert.Equal(t, "Let’s build", parsed.TableOfContents[0].Text)
+}
+
+func TestGoldmarkParser_ParseMarkdown_WithExtensions(t *testing.T) {
+	parser := NewGoldmarkParser(WithExtensions(ExtensionFootnotes))
+
+	parsed, err := parser.ParseMarkdown("~~deprecated~~ :rocket:\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>~~deprecated~~ :rocket:</p>\n", parsed.Content)
+}
+
+func TestParseExtensions(t *testing.T) {
+	extensions, err := ParseExtensions([]string{"GFM", " emoji "})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Extension{ExtensionGFM, ExtensionEmoji}, extensions)
+
+	_, err = ParseExtensions([]string{"gfm", "mathjax"})
+	assert.ErrorIs(t, err, ErrUnknownExtension)
+}
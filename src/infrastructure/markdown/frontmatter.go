@@ -0,0 +1,234 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+)
+
+// ValidationMode controls whether invalid frontmatter fails the parse or is reported as warnings
+type ValidationMode string
+
+// Validation modes
+const (
+	// ValidationWarn reports invalid fields as warnings and keeps the valid metadata
+	ValidationWarn ValidationMode = "warn"
+	// ValidationFail fails the parse of documents with invalid fields
+	ValidationFail ValidationMode = "fail"
+)
+
+// Frontmatter errors
+var (
+	ErrInvalidFrontmatter    = errors.New("invalid frontmatter")
+	ErrUnknownValidationMode = errors.New("unknown validation mode")
+)
+
+// slugPattern matches anchors: lowercase words joined by single dashes
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// langPattern matches BCP 47 language tags such as en, de-AT or zh-Hant-TW
+var langPattern = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+
+// frontmatterMeta is the frontmatter schema of a post
+type frontmatterMeta struct {
+	Title        string   `yaml:"title"`
+	Slug         string   `yaml:"slug"`
+	Aliases      []string `yaml:"aliases"`
+	Date         string   `yaml:"date"`
+	Updated      string   `yaml:"updated"`
+	Description  string   `yaml:"description"`
+	Tags         []string `yaml:"tags"`
+	Author       string   `yaml:"author"`
+	CoverImage   string   `yaml:"cover_image"`
+	CanonicalURL string   `yaml:"canonical_url"`
+	Lang         string   `yaml:"lang"`
+	Draft        bool     `yaml:"draft"`
+	Unlisted     bool     `yaml:"unlisted"`
+
+	// ReadingTime overrides the estimated reading time in minutes
+	ReadingTime int `yaml:"reading_time"`
+}
+
+// FieldError describes an invalid frontmatter field of a document
+type FieldError struct {
+	Filename string
+	Field    string
+	Message  string
+}
+
+// Error implements error
+func (e *FieldError) Error() string {
+	if e.Filename == "" {
+		return fmt.Sprintf("frontmatter field %q %s", e.Field, e.Message)
+	}
+	return fmt.Sprintf("%s: frontmatter field %q %s", e.Filename, e.Field, e.Message)
+}
+
+// Unwrap makes field errors match ErrInvalidFrontmatter
+func (e *FieldError) Unwrap() error {
+	return ErrInvalidFrontmatter
+}
+
+// ParseValidationMode converts a validation mode name into a ValidationMode
+func ParseValidationMode(name string) (ValidationMode, error) {
+	switch mode := ValidationMode(strings.ToLower(strings.TrimSpace(name))); mode {
+	case ValidationWarn, ValidationFail:
+		return mode, nil
+	case "":
+		return ValidationWarn, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownValidationMode, name)
+	}
+}
+
+// WithValidationMode sets how invalid frontmatter is handled
+func WithValidationMode(mode ValidationMode) Option {
+	return func(p *GoldmarkParser) {
+		p.validationMode = mode
+	}
+}
+
+// frontmatterFields lists the keys of the frontmatter schema
+var frontmatterFields = func() []string {
+	var fields []string
+	metaType := reflect.TypeOf(frontmatterMeta{})
+	for i := range metaType.NumField() {
+		fields = append(fields, metaType.Field(i).Tag.Get("yaml"))
+	}
+	return fields
+}()
+
+// fieldTypes describes the types of the frontmatter schema in type errors
+var fieldTypes = map[reflect.Type]string{
+	reflect.TypeOf(""):         "a string",
+	reflect.TypeOf([]string{}): "a list of strings",
+	reflect.TypeOf(false):      "a boolean",
+	reflect.TypeOf(0):          "an integer",
+}
+
+// decodeFrontmatter decodes the frontmatter into the schema. When a value has the wrong type,
+// the fields are decoded one by one, so the mismatch is reported as a field error and the other fields are kept.
+func decodeFrontmatter(filename string, decode func(any) error) (frontmatterMeta, []*FieldError) {
+	var meta frontmatterMeta
+	if err := decode(&meta); err == nil {
+		return meta, nil
+	}
+
+	meta = frontmatterMeta{}
+	var fieldErrors []*FieldError
+	value := reflect.ValueOf(&meta).Elem()
+	for i := range value.NumField() {
+		field := value.Type().Field(i)
+		single := reflect.New(reflect.StructOf([]reflect.StructField{field}))
+		if err := decode(single.Interface()); err != nil {
+			fieldErrors = append(fieldErrors, &FieldError{
+				Filename: filename,
+				Field:    field.Tag.Get("yaml"),
+				Message:  "must be " + fieldTypes[field.Type],
+			})
+			continue
+		}
+		value.Field(i).Set(single.Elem().Field(0))
+	}
+	return meta, fieldErrors
+}
+
+// validateFrontmatter checks the frontmatter of a document against the schema.
+// The raw keys are checked for unknown fields, which decoding into the schema silently drops.
+func validateFrontmatter(filename string, meta frontmatterMeta, keys []string) []*FieldError {
+	var fieldErrors []*FieldError
+	fail := func(field, format string, args ...any) {
+		fieldErrors = append(fieldErrors, &FieldError{
+			Filename: filename,
+			Field:    field,
+			Message:  fmt.Sprintf(format, args...),
+		})
+	}
+
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !slices.Contains(frontmatterFields, key) {
+			fail(key, "is not a known field")
+		}
+	}
+
+	if strings.TrimSpace(meta.Title) == "" {
+		fail("title", "is required")
+	}
+
+	if meta.Slug != "" && !slugPattern.MatchString(meta.Slug) {
+		fail("slug", "is not a valid slug: %q", meta.Slug)
+	}
+
+	aliases := make(map[string]bool)
+	for _, alias := range meta.Aliases {
+		switch {
+		case !slugPattern.MatchString(alias):
+			fail("aliases", "contains the invalid slug %q", alias)
+		case aliases[alias]:
+			fail("aliases", "contains the duplicate alias %q", alias)
+		case alias == meta.Slug:
+			fail("aliases", "contains the slug %q of the post itself", alias)
+		}
+		aliases[alias] = true
+	}
+
+	var date time.Time
+	if strings.TrimSpace(meta.Date) == "" {
+		fail("date", "is required")
+	} else if parsed, err := blog.ParseDate(meta.Date); err != nil {
+		fail("date", "is not a valid date: %q", meta.Date)
+	} else {
+		date = parsed
+	}
+
+	if meta.Updated != "" {
+		if updated, err := blog.ParseDate(meta.Updated); err != nil {
+			fail("updated", "is not a valid date: %q", meta.Updated)
+		} else if !date.IsZero() && updated.Before(date) {
+			fail("updated", "is before date")
+		}
+	}
+
+	seen := make(map[string]bool)
+	for _, tag := range meta.Tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		switch {
+		case tag == "":
+			fail("tags", "contains an empty tag")
+		case seen[tag]:
+			fail("tags", "contains the duplicate tag %q", tag)
+		}
+		seen[tag] = true
+	}
+
+	if meta.CoverImage != "" {
+		if u, err := url.Parse(meta.CoverImage); err != nil || (u.IsAbs() && u.Scheme != "https" && u.Scheme != "http") {
+			fail("cover_image", "is not a valid image URL or path: %q", meta.CoverImage)
+		}
+	}
+
+	if meta.CanonicalURL != "" {
+		if u, err := url.Parse(meta.CanonicalURL); err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+			fail("canonical_url", "is not an absolute http(s) URL: %q", meta.CanonicalURL)
+		}
+	}
+
+	if meta.Lang != "" && !langPattern.MatchString(meta.Lang) {
+		fail("lang", "is not a valid language tag: %q", meta.Lang)
+	}
+
+	if meta.ReadingTime < 0 {
+		fail("reading_time", "must not be negative")
+	}
+
+	return fieldErrors
+}
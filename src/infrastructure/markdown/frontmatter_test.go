@@ -0,0 +1,209 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldmarkParser_ParseMarkdown_FullFrontmatter(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `---
+title: Test Title
+date: 16.05.2024
+updated: 01.06.2024
+description: A post about testing
+tags: [go, testing]
+author: Aliaksandr Kavaliou
+cover_image: /posts/assets/cover.png
+canonical_url: https://dev.to/buyallmemes/test-title
+lang: en-US
+---
+Hello, World!`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Empty(t, parsed.Warnings)
+	assert.Equal(t, "A post about testing", parsed.Description)
+	assert.Equal(t, []string{"go", "testing"}, parsed.Tags)
+	assert.Equal(t, "Aliaksandr Kavaliou", parsed.Author)
+	assert.Equal(t, "/posts/assets/cover.png", parsed.CoverImage)
+	assert.Equal(t, "https://dev.to/buyallmemes/test-title", parsed.CanonicalURL)
+	assert.Equal(t, "en-US", parsed.Lang)
+}
+
+const invalidFrontmatter = `---
+title: ""
+date: 16.05.2024
+updated: 01.05.2024
+tags: [go, Go, ""]
+canonical_url: /relative
+lang: english!
+subtitle: Unknown
+---
+Hello, World!`
+
+func TestGoldmarkParser_ParseDocument_FrontmatterWarnings(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseDocument(context.Background(), blog.Document{
+		Filename: "20240516-post.md",
+		Content:  invalidFrontmatter,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`frontmatter field "subtitle" is not a known field`,
+		`frontmatter field "title" is required`,
+		`frontmatter field "updated" is before date`,
+		`frontmatter field "tags" contains the duplicate tag "go"`,
+		`frontmatter field "tags" contains an empty tag`,
+		`frontmatter field "canonical_url" is not an absolute http(s) URL: "/relative"`,
+		`frontmatter field "lang" is not a valid language tag: "english!"`,
+	}, parsed.Warnings)
+	assert.Equal(t, "16.05.2024", parsed.Date)
+	assert.Equal(t, "<p>Hello, World!</p>\n", parsed.Content)
+}
+
+func TestGoldmarkParser_ParseDocument_FrontmatterFail(t *testing.T) {
+	parser := NewGoldmarkParser(WithValidationMode(ValidationFail))
+
+	parsed, err := parser.ParseDocument(context.Background(), blog.Document{
+		Filename: "20240516-post.md",
+		Content:  invalidFrontmatter,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidFrontmatter)
+	assert.Contains(t, err.Error(), `20240516-post.md: frontmatter field "title" is required`)
+	assert.Empty(t, parsed.Date)
+
+	var fieldError *FieldError
+	assert.True(t, errors.As(err, &fieldError))
+	assert.Equal(t, "20240516-post.md", fieldError.Filename)
+	assert.Equal(t, "subtitle", fieldError.Field)
+}
+
+const mistypedFrontmatter = `---
+title: [Test, Title]
+date: 16.05.2024
+tags: foo
+draft: sometimes
+reading_time: soon
+author: Aliaksandr Kavaliou
+---
+Hello, World!`
+
+func TestGoldmarkParser_ParseDocument_FrontmatterTypeWarnings(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseDocument(context.Background(), blog.Document{
+		Filename: "20240516-post.md",
+		Content:  mistypedFrontmatter,
+	})
+
+	// Mistyped fields are reported and dropped, the other fields are kept
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`frontmatter field "title" must be a string`,
+		`frontmatter field "tags" must be a list of strings`,
+		`frontmatter field "draft" must be a boolean`,
+		`frontmatter field "reading_time" must be an integer`,
+	}, parsed.Warnings)
+	assert.Equal(t, "16.05.2024", parsed.Date)
+	assert.Equal(t, "Aliaksandr Kavaliou", parsed.Author)
+	assert.Empty(t, parsed.Tags)
+	assert.False(t, parsed.Draft)
+}
+
+func TestGoldmarkParser_ParseDocument_FrontmatterTypeFail(t *testing.T) {
+	parser := NewGoldmarkParser(WithValidationMode(ValidationFail))
+
+	_, err := parser.ParseDocument(context.Background(), blog.Document{
+		Filename: "20240516-post.md",
+		Content:  mistypedFrontmatter,
+	})
+
+	assert.ErrorIs(t, err, ErrInvalidFrontmatter)
+	assert.NotErrorIs(t, err, ErrFrontmatterDecoding)
+
+	var fieldError *FieldError
+	assert.True(t, errors.As(err, &fieldError))
+	assert.Equal(t, "20240516-post.md", fieldError.Filename)
+	assert.Equal(t, "title", fieldError.Field)
+	assert.Contains(t, err.Error(), `20240516-post.md: frontmatter field "draft" must be a boolean`)
+}
+
+func TestGoldmarkParser_ParseDocument_MissingFrontmatter(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseDocument(context.Background(), blog.Document{
+		Filename: "20240516-post.md",
+		Content:  "Hello, World!",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`frontmatter field "title" is required`,
+		`frontmatter field "date" is required`,
+	}, parsed.Warnings)
+}
+
+func TestParseValidationMode(t *testing.T) {
+	mode, err := ParseValidationMode(" Fail ")
+	assert.NoError(t, err)
+	assert.Equal(t, ValidationFail, mode)
+
+	mode, err = ParseValidationMode("")
+	assert.NoError(t, err)
+	assert.Equal(t, ValidationWarn, mode)
+
+	_, err = ParseValidationMode("ignore")
+	assert.ErrorIs(t, err, ErrUnknownValidationMode)
+}
+
+func TestGoldmarkParser_ParseMarkdown_SlugAndAliases(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `---
+title: Ultimate Testing Guideline
+date: 16.05.2024
+slug: testing-guideline
+aliases: [testing, ultimate-testing-guideline]
+---
+Hello, World!`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Empty(t, parsed.Warnings)
+	assert.Equal(t, "testing-guideline", parsed.Anchor)
+	assert.Equal(t, []string{"testing", "ultimate-testing-guideline"}, parsed.Aliases)
+}
+
+func TestGoldmarkParser_ParseMarkdown_InvalidSlug(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `---
+title: Testing Guideline
+date: 16.05.2024
+slug: Testing Guideline
+aliases: [testing-guideline, /posts/old]
+---
+Hello, World!`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		`frontmatter field "slug" is not a valid slug: "Testing Guideline"`,
+		`frontmatter field "aliases" contains the invalid slug "/posts/old"`,
+	}, parsed.Warnings)
+	// The invalid slug falls back to the title, which makes the alias the anchor itself
+	assert.Equal(t, "testing-guideline", parsed.Anchor)
+	assert.Empty(t, parsed.Aliases)
+}
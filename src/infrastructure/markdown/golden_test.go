@@ -0,0 +1,49 @@
+package markdown
+
+import (
+	"flag"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// update regenerates the golden files: go test ./src/infrastructure/markdown -run TestGoldenPosts -update
+var update = flag.Bool("update", false, "update golden files")
+
+const (
+	postsDir  = "../../../posts"
+	goldenDir = "testdata/golden"
+)
+
+func TestGoldenPosts(t *testing.T) {
+	files, err := filepath.Glob(filepath.Join(postsDir, "*.md"))
+	assert.NoError(t, err)
+	assert.NotEmpty(t, files)
+
+	parser := NewGoldmarkParser()
+
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".md")
+
+		t.Run(name, func(t *testing.T) {
+			source, err := os.ReadFile(file)
+			assert.NoError(t, err)
+
+			parsed, err := parser.ParseMarkdown(string(source))
+			assert.NoError(t, err)
+
+			goldenFile := filepath.Join(goldenDir, name+".html")
+			if *update {
+				assert.NoError(t, os.MkdirAll(goldenDir, 0o755))
+				assert.NoError(t, os.WriteFile(goldenFile, []byte(parsed.Content), 0o644))
+			}
+
+			golden, err := os.ReadFile(goldenFile)
+			assert.NoError(t, err)
+			assert.Equal(t, string(golden), parsed.Content)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
+	"github.com/alecthomas/chroma/v2/styles"
+	"github.com/yuin/goldmark"
+	highlighting "github.com/yuin/goldmark-highlighting/v2"
+)
+
+// DefaultHighlightStyle is the chroma style used when none is configured
+const DefaultHighlightStyle = "github"
+
+// ErrUnknownHighlightStyle is returned when a chroma style does not exist
+var ErrUnknownHighlightStyle = errors.New("unknown highlight style")
+
+// HighlightConfig holds the configuration for syntax highlighting of fenced code blocks
+type HighlightConfig struct {
+	// Enabled toggles syntax highlighting
+	Enabled bool
+
+	// Style is the chroma style name, e.g. "github" or "monokai"
+	Style string
+
+	// CSSClasses emits CSS classes instead of inline styles, so themes can provide the colors
+	CSSClasses bool
+
+	// LineNumbers prefixes every line of code with its number
+	LineNumbers bool
+}
+
+// DefaultHighlightConfig returns the default highlighting configuration
+func DefaultHighlightConfig() HighlightConfig {
+	return HighlightConfig{
+		Enabled:     true,
+		Style:       DefaultHighlightStyle,
+		CSSClasses:  true,
+		LineNumbers: false,
+	}
+}
+
+// WithHighlighting configures syntax highlighting of fenced code blocks
+func WithHighlighting(config HighlightConfig) Option {
+	return func(p *GoldmarkParser) {
+		p.highlighting = config
+	}
+}
+
+// extension creates the goldmark highlighting extension.
+// Highlighted lines are taken from fence attributes, e.g. ```go {hl_lines=[3,5]}
+func (c HighlightConfig) extension() goldmark.Extender {
+	style := c.Style
+	if style == "" {
+		style = DefaultHighlightStyle
+	}
+
+	return highlighting.NewHighlighting(
+		highlighting.WithStyle(style),
+		highlighting.WithFormatOptions(
+			chromahtml.WithClasses(c.CSSClasses),
+			chromahtml.WithLineNumbers(c.LineNumbers),
+		),
+	)
+}
+
+// HighlightCSS returns the stylesheet for code highlighted with CSS classes in the given style
+func HighlightCSS(style string) (string, error) {
+	chromaStyle, ok := styles.Registry[strings.ToLower(style)]
+	if !ok {
+		return "", fmt.Errorf("%w: %s", ErrUnknownHighlightStyle, style)
+	}
+
+	var css strings.Builder
+	if err := chromahtml.New(chromahtml.WithClasses(true)).WriteCSS(&css, chromaStyle); err != nil {
+		return "", fmt.Errorf("error writing highlight CSS: %w", err)
+	}
+	return css.String(), nil
+}
@@ -0,0 +1,64 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const goSnippet = "```go {hl_lines=[2]}\n" +
+	"func main() {\n" +
+	"\tprintln(\"Hello, World!\")\n" +
+	"}\n" +
+	"```\n"
+
+func TestGoldmarkParser_ParseMarkdown_Highlighting(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown(goSnippet)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<pre class="chroma">`)
+	assert.Contains(t, parsed.Content, `<span class="kd">func</span>`)
+	assert.Contains(t, parsed.Content, `<span class="line hl">`)
+	assert.NotContains(t, parsed.Content, `style="`)
+	assert.NotContains(t, parsed.Content, `class="ln"`)
+}
+
+func TestGoldmarkParser_ParseMarkdown_HighlightingInlineStylesAndLineNumbers(t *testing.T) {
+	parser := NewGoldmarkParser(WithHighlighting(HighlightConfig{
+		Enabled:     true,
+		Style:       "monokai",
+		LineNumbers: true,
+	}))
+
+	parsed, err := parser.ParseMarkdown(goSnippet)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `style="`)
+	assert.NotContains(t, parsed.Content, `class="chroma"`)
+	assert.Contains(t, parsed.Content, ">1</span>")
+}
+
+func TestGoldmarkParser_ParseMarkdown_HighlightingDisabled(t *testing.T) {
+	parser := NewGoldmarkParser(WithHighlighting(HighlightConfig{Enabled: false}))
+
+	parsed, err := parser.ParseMarkdown(goSnippet)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<pre><code class="language-go">`)
+}
+
+func TestHighlightCSS(t *testing.T) {
+	css, err := HighlightCSS("monokai")
+
+	assert.NoError(t, err)
+	assert.Contains(t, css, ".chroma")
+	assert.Contains(t, css, ".hl")
+}
+
+func TestHighlightCSS_UnknownStyle(t *testing.T) {
+	_, err := HighlightCSS("no-such-style")
+
+	assert.ErrorIs(t, err, ErrUnknownHighlightStyle)
+}
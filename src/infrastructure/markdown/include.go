@@ -0,0 +1,98 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// includeLanguagePattern matches the languages of included code, without spaces or backticks ending the info string
+var includeLanguagePattern = regexp.MustCompile(`^[A-Za-z0-9_+#.-]*$`)
+
+// IncludeShortcode renders a file of the post source as a code block:
+// {{< include "examples/foo.go" lines="10-30" lang="go" >}}
+type IncludeShortcode struct {
+	// renderCode renders a fenced code block, highlighted like the rest of the post
+	renderCode func(code, language string) (string, error)
+}
+
+// Name implements Shortcode
+func (s *IncludeShortcode) Name() string {
+	return "include"
+}
+
+// Render implements Shortcode
+func (s *IncludeShortcode) Render(ctx context.Context, call ShortcodeCall) (string, error) {
+	name := call.Arg(0)
+	if name == "" {
+		return "", errors.New("missing file path")
+	}
+	if call.Document.Files == nil {
+		return "", fmt.Errorf("cannot read %s: no file reader", name)
+	}
+
+	content, err := call.Document.Files.ReadFile(ctx, name)
+	if err != nil {
+		return "", err
+	}
+
+	code := strings.TrimRight(strings.ReplaceAll(string(content), "\r\n", "\n"), "\n")
+	if lines, ok := call.Params["lines"]; ok {
+		code, err = selectLines(code, lines)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	language, ok := call.Params["lang"]
+	if !ok {
+		language = strings.TrimPrefix(path.Ext(name), ".")
+	}
+	// Included files are rendered as code only, never as diagrams
+	if !includeLanguagePattern.MatchString(language) ||
+		slices.Contains([]string{DiagramMermaid, DiagramPlantUML}, strings.ToLower(language)) {
+		return "", fmt.Errorf("invalid code language %q", language)
+	}
+
+	return s.renderCode(code, language)
+}
+
+// selectLines returns the 1-based inclusive line range "from-to" of the code.
+// "n" selects a single line and "n-" the lines from n to the end.
+func selectLines(code, lines string) (string, error) {
+	all := strings.Split(code, "\n")
+
+	fromValue, toValue, isRange := strings.Cut(lines, "-")
+	from, err := strconv.Atoi(strings.TrimSpace(fromValue))
+	if err != nil {
+		return "", fmt.Errorf("invalid line range %q", lines)
+	}
+	to := from
+	if isRange {
+		to = len(all)
+		if strings.TrimSpace(toValue) != "" {
+			if to, err = strconv.Atoi(strings.TrimSpace(toValue)); err != nil {
+				return "", fmt.Errorf("invalid line range %q", lines)
+			}
+		}
+	}
+
+	if from < 1 || to < from || to > len(all) {
+		return "", fmt.Errorf("line range %q out of bounds, the file has %d lines", lines, len(all))
+	}
+	return strings.Join(all[from-1:to], "\n"), nil
+}
+
+// fencedCodeBlock wraps code in a fence longer than any backtick run inside it
+func fencedCodeBlock(code, language string) string {
+	fence := "```"
+	for strings.Contains(code, fence) {
+		fence += "`"
+	}
+	return fence + language + "\n" + code + "\n" + fence + "\n"
+}
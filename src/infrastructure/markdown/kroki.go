@@ -0,0 +1,68 @@
+package markdown
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ErrDiagramRendering is returned when a diagram cannot be rendered
+var ErrDiagramRendering = errors.New("diagram rendering failed")
+
+// krokiTimeout bounds a single diagram rendering request, well within the deadline of a whole load
+const krokiTimeout = 2 * time.Second
+
+// KrokiRenderer renders diagrams to SVG through a Kroki server (https://kroki.io)
+type KrokiRenderer struct {
+	baseURL string
+	client  *http.Client
+	timeout time.Duration
+}
+
+// NewKrokiRenderer creates a new KrokiRenderer for the Kroki server at baseURL
+func NewKrokiRenderer(baseURL string, client *http.Client) *KrokiRenderer {
+	if client == nil {
+		client = &http.Client{}
+	}
+
+	return &KrokiRenderer{
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+		client:  client,
+		timeout: krokiTimeout,
+	}
+}
+
+// Render renders the diagram source to SVG. The request is bounded by krokiTimeout and the deadline of ctx,
+// whichever comes first, so a slow Kroki server fails the diagram and not the load of the posts.
+func (r *KrokiRenderer) Render(ctx context.Context, language, source string) (string, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	url := fmt.Sprintf("%s/%s/svg", r.baseURL, language)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, strings.NewReader(source))
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDiagramRendering, err)
+	}
+	req.Header.Set("Content-Type", "text/plain")
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDiagramRendering, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrDiagramRendering, err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("%w: non 200 response code: %v: %s", ErrDiagramRendering, resp.StatusCode, strings.TrimSpace(string(body)))
+	}
+
+	return string(body), nil
+}
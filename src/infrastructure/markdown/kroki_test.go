@@ -0,0 +1,55 @@
+package markdown
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestKrokiRenderer_Render(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/mermaid/svg", r.URL.Path)
+		assert.Equal(t, "graph TD;", string(body))
+		_, _ = w.Write([]byte("<svg></svg>"))
+	}))
+	defer server.Close()
+
+	svg, err := NewKrokiRenderer(server.URL+"/", nil).Render(context.Background(), DiagramMermaid, "graph TD;")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<svg></svg>", svg)
+}
+
+func TestKrokiRenderer_RenderError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, "Syntax error in graph", http.StatusBadRequest)
+	}))
+	defer server.Close()
+
+	_, err := NewKrokiRenderer(server.URL, nil).Render(context.Background(), DiagramMermaid, "graph")
+
+	assert.ErrorIs(t, err, ErrDiagramRendering)
+	assert.Contains(t, err.Error(), "Syntax error in graph")
+}
+
+func TestKrokiRenderer_RenderTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(500 * time.Millisecond)
+	}))
+	defer server.Close()
+	renderer := NewKrokiRenderer(server.URL, nil)
+	renderer.timeout = 50 * time.Millisecond
+
+	start := time.Now()
+	_, err := renderer.Render(context.Background(), DiagramMermaid, "graph TD;")
+
+	assert.ErrorIs(t, err, ErrDiagramRendering)
+	assert.Less(t, time.Since(start), 500*time.Millisecond)
+}
@@ -0,0 +1,318 @@
+package markdown
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ExtensionMath renders $inline$ and $$display$$ TeX formulas as KaTeX-ready markup
+const ExtensionMath Extension = "math"
+
+// Math node kinds
+var (
+	KindMathInline = ast.NewNodeKind("MathInline")
+	KindMathBlock  = ast.NewNodeKind("MathBlock")
+)
+
+// MathInline is an inline $…$ formula
+type MathInline struct {
+	ast.BaseInline
+	TeX     []byte
+	Offset  int
+	Invalid bool
+}
+
+// Kind implements ast.Node
+func (n *MathInline) Kind() ast.NodeKind {
+	return KindMathInline
+}
+
+// Dump implements ast.Node
+func (n *MathInline) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"TeX": string(n.TeX)}, nil)
+}
+
+// MathBlock is a display $$…$$ formula
+type MathBlock struct {
+	ast.BaseBlock
+	Invalid bool
+}
+
+// Kind implements ast.Node
+func (n *MathBlock) Kind() ast.NodeKind {
+	return KindMathBlock
+}
+
+// IsRaw implements ast.Node
+func (n *MathBlock) IsRaw() bool {
+	return true
+}
+
+// Dump implements ast.Node
+func (n *MathBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, nil, nil)
+}
+
+// mathExtension registers the math parsers and renderer
+type mathExtension struct{}
+
+// Extend implements goldmark.Extender
+func (e *mathExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&mathBlockParser{}, 90)),
+		parser.WithInlineParsers(util.Prioritized(&mathInlineParser{}, 150)),
+		parser.WithASTTransformers(util.Prioritized(&mathValidator{}, 100)),
+	)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(util.Prioritized(&mathRenderer{}, 100)),
+	)
+}
+
+// mathInlineParser parses $…$ spans. Like pandoc, the opening $ must be followed by a non-space
+// and the closing $ preceded by a non-space and not followed by a digit, so prices like $5 stay text.
+type mathInlineParser struct{}
+
+// Trigger implements parser.InlineParser
+func (p *mathInlineParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Parse implements parser.InlineParser
+func (p *mathInlineParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, segment := block.PeekLine()
+	if len(line) < 3 || line[1] == '$' || util.IsSpace(line[1]) {
+		return nil
+	}
+
+	for i := 2; i < len(line); i++ {
+		switch {
+		case line[i] == '\\':
+			i++
+		case line[i] == '$':
+			if util.IsSpace(line[i-1]) || (i+1 < len(line) && line[i+1] >= '0' && line[i+1] <= '9') {
+				return nil
+			}
+			node := &MathInline{TeX: line[1:i], Offset: segment.Start}
+			block.Advance(i + 1)
+			return node
+		}
+	}
+	return nil
+}
+
+// mathBlockParser parses display formulas fenced by $$ lines
+type mathBlockParser struct{}
+
+// Trigger implements parser.BlockParser
+func (p *mathBlockParser) Trigger() []byte {
+	return []byte{'$'}
+}
+
+// Open implements parser.BlockParser
+func (p *mathBlockParser) Open(_ ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	pos := pc.BlockOffset()
+	if pos < 0 || !bytes.HasPrefix(line[pos:], []byte("$$")) {
+		return nil, parser.NoChildren
+	}
+
+	node := &MathBlock{}
+	rest := bytes.TrimSpace(line[pos+2:])
+	if len(rest) == 0 {
+		return node, parser.NoChildren
+	}
+
+	// Single line formula: $$ … $$
+	if !bytes.HasSuffix(rest, []byte("$$")) || len(rest) < 3 {
+		return nil, parser.NoChildren
+	}
+	start := segment.Start + pos + 2 + bytes.Index(line[pos+2:], rest[:1])
+	node.Lines().Append(text.NewSegment(start, start+len(rest)-2))
+	reader.Advance(segment.Len() - 1)
+	return node, parser.Close
+}
+
+// Continue implements parser.BlockParser
+func (p *mathBlockParser) Continue(node ast.Node, reader text.Reader, _ parser.Context) parser.State {
+	line, segment := reader.PeekLine()
+	if bytes.Equal(bytes.TrimSpace(line), []byte("$$")) {
+		reader.Advance(segment.Len() - 1)
+		return parser.Close
+	}
+
+	node.Lines().Append(segment)
+	reader.Advance(segment.Len() - 1)
+	return parser.Continue | parser.NoChildren
+}
+
+// Close implements parser.BlockParser
+func (p *mathBlockParser) Close(ast.Node, text.Reader, parser.Context) {}
+
+// CanInterruptParagraph implements parser.BlockParser
+func (p *mathBlockParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser
+func (p *mathBlockParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// mathValidator checks every formula and records a warning for invalid TeX
+type mathValidator struct{}
+
+// Transform implements parser.ASTTransformer
+func (v *mathValidator) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *MathInline:
+			if err := validateTeX(string(n.TeX)); err != nil {
+				n.Invalid = true
+				addWarning(pc, source, n.Offset, "invalid TeX %q: %v", n.TeX, err)
+			}
+		case *MathBlock:
+			if err := validateTeX(string(n.Lines().Value(source))); err != nil {
+				n.Invalid = true
+				addWarning(pc, source, n.Lines().At(0).Start, "invalid TeX block: %v", err)
+			}
+		}
+		return ast.WalkContinue, nil
+	})
+}
+
+// validateTeX performs a structural check of a TeX formula: it must not be empty,
+// braces must balance and \begin/\end and \left/\right must pair up
+func validateTeX(tex string) error {
+	if strings.TrimSpace(tex) == "" {
+		return fmt.Errorf("empty formula")
+	}
+
+	depth := 0
+	leftRight := 0
+	var environments []string
+
+	for i := 0; i < len(tex); i++ {
+		switch tex[i] {
+		case '{':
+			depth++
+		case '}':
+			depth--
+			if depth < 0 {
+				return fmt.Errorf("unexpected '}' at position %d", i)
+			}
+		case '\\':
+			command := texCommand(tex[i+1:])
+			switch command {
+			case "left":
+				leftRight++
+			case "right":
+				leftRight--
+				if leftRight < 0 {
+					return fmt.Errorf("\\right without \\left")
+				}
+			case "begin", "end":
+				name, ok := texArgument(tex[i+1+len(command):])
+				if !ok {
+					return fmt.Errorf("\\%s without environment name", command)
+				}
+				if command == "begin" {
+					environments = append(environments, name)
+				} else {
+					if len(environments) == 0 || environments[len(environments)-1] != name {
+						return fmt.Errorf("unexpected \\end{%s}", name)
+					}
+					environments = environments[:len(environments)-1]
+				}
+			}
+			// Skip the command name, or the escaped character
+			i += max(len(command), 1)
+		}
+	}
+
+	switch {
+	case depth > 0:
+		return fmt.Errorf("unbalanced braces")
+	case leftRight > 0:
+		return fmt.Errorf("\\left without \\right")
+	case len(environments) > 0:
+		return fmt.Errorf("unclosed environment %q", environments[len(environments)-1])
+	}
+	return nil
+}
+
+// texCommand returns the letters of the command name at the start of s
+func texCommand(s string) string {
+	i := 0
+	for i < len(s) && (s[i] >= 'a' && s[i] <= 'z' || s[i] >= 'A' && s[i] <= 'Z') {
+		i++
+	}
+	return s[:i]
+}
+
+// texArgument returns the content of the {…} group at the start of s
+func texArgument(s string) (string, bool) {
+	s = strings.TrimLeft(s, " ")
+	end := strings.IndexByte(s, '}')
+	if !strings.HasPrefix(s, "{") || end < 0 {
+		return "", false
+	}
+	return s[1:end], true
+}
+
+// mathRenderer renders formulas as KaTeX-ready markup. The TeX source is kept inside
+// \(…\) and \[…\] delimiters, so the formula stays readable when no script runs.
+type mathRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer
+func (r *mathRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindMathInline, r.renderInline)
+	reg.Register(KindMathBlock, r.renderBlock)
+}
+
+// renderInline renders an inline formula
+func (r *mathRenderer) renderInline(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*MathInline)
+	if n.Invalid {
+		_, _ = fmt.Fprintf(w, `<code class="math math-error">$%s$</code>`, html.EscapeString(string(n.TeX)))
+		return ast.WalkSkipChildren, nil
+	}
+
+	_, _ = fmt.Fprintf(w, `<span class="math math-inline">\(%s\)</span>`, html.EscapeString(string(n.TeX)))
+	return ast.WalkSkipChildren, nil
+}
+
+// renderBlock renders a display formula
+func (r *mathRenderer) renderBlock(w util.BufWriter, source []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if !entering {
+		return ast.WalkContinue, nil
+	}
+
+	n := node.(*MathBlock)
+	tex := html.EscapeString(strings.TrimSpace(string(n.Lines().Value(source))))
+	if n.Invalid {
+		_, _ = fmt.Fprintf(w, "<pre class=\"math math-error\">$$\n%s\n$$</pre>\n", tex)
+		return ast.WalkSkipChildren, nil
+	}
+
+	_, _ = fmt.Fprintf(w, "<div class=\"math math-display\">\\[%s\\]</div>\n", tex)
+	return ast.WalkSkipChildren, nil
+}
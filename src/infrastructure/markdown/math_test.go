@@ -0,0 +1,74 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldmarkParser_ParseMarkdown_InlineMath(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("Euler: $e^{i\\pi} + 1 = 0$, it costs $5 and $10.")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>Euler: <span class=\"math math-inline\">\\(e^{i\\pi} + 1 = 0\\)</span>, it costs $5 and $10.</p>\n", parsed.Content)
+	assert.Empty(t, parsed.Warnings)
+}
+
+func TestGoldmarkParser_ParseMarkdown_DisplayMath(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `Before
+
+$$
+\sum_{i=1}^{n} i = \frac{n(n+1)}{2}
+$$
+
+$$ a < b $$
+`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<div class=\"math math-display\">\\[\\sum_{i=1}^{n} i = \\frac{n(n+1)}{2}\\]</div>\n")
+	assert.Contains(t, parsed.Content, "<div class=\"math math-display\">\\[a &lt; b\\]</div>\n")
+	assert.Equal(t, 1, parsed.WordCount)
+	assert.Empty(t, parsed.Warnings)
+}
+
+func TestGoldmarkParser_ParseMarkdown_InvalidMath(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := "First line\n\nBroken $\\frac{1}{2$ formula\n\n$$\n\\begin{matrix} a \\end{pmatrix}\n$$\n"
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<code class="math math-error">$\frac{1}{2$</code>`)
+	assert.Contains(t, parsed.Content, `<pre class="math math-error">`)
+	assert.Equal(t, []string{
+		`line 3: invalid TeX "\\frac{1}{2": unbalanced braces`,
+		`line 6: invalid TeX block: unexpected \end{pmatrix}`,
+	}, parsed.Warnings)
+}
+
+func TestGoldmarkParser_ParseMarkdown_MathDisabled(t *testing.T) {
+	parser := NewGoldmarkParser(WithExtensions(ExtensionGFM))
+
+	parsed, err := parser.ParseMarkdown("$x$")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "<p>$x$</p>\n", parsed.Content)
+}
+
+func TestValidateTeX(t *testing.T) {
+	assert.NoError(t, validateTeX(`\left( \frac{a}{b} \right)`))
+	assert.NoError(t, validateTeX(`\begin{cases} 1 \\ 2 \end{cases}`))
+	assert.NoError(t, validateTeX(`\{ a \}`))
+	assert.Error(t, validateTeX(" "))
+	assert.Error(t, validateTeX(`a}`))
+	assert.Error(t, validateTeX(`\left( a`))
+	assert.Error(t, validateTeX(`\right) a`))
+	assert.Error(t, validateTeX(`\begin{cases} a`))
+}
@@ -0,0 +1,103 @@
+package markdown
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/yuin/goldmark/ast"
+)
+
+// DefaultWordsPerMinute is the default reading speed used to estimate reading time
+const DefaultWordsPerMinute = 200
+
+// documentMetrics holds the reading metrics and the plain text of a markdown document
+type documentMetrics struct {
+	words      int
+	images     int
+	codeBlocks int
+	plainText  string
+}
+
+// collectMetrics counts the words, images and code blocks of a document and extracts its plain text.
+// Words are counted over the readable text only, code blocks and raw HTML are left out.
+func collectMetrics(doc ast.Node, source []byte) documentMetrics {
+	metrics := documentMetrics{}
+	var words, plain strings.Builder
+
+	write := func(value string) {
+		words.WriteString(value)
+		plain.WriteString(value)
+	}
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			// Separate the text of consecutive blocks
+			if node.Type() == ast.TypeBlock {
+				words.WriteByte(' ')
+				plain.WriteString("\n\n")
+			}
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.FencedCodeBlock, *ast.CodeBlock:
+			metrics.codeBlocks++
+			// Code is part of the plain text, but it is not read as prose
+			plain.Write(n.Lines().Value(source))
+			plain.WriteString("\n\n")
+			return ast.WalkSkipChildren, nil
+		case *ast.Image:
+			metrics.images++
+			return ast.WalkSkipChildren, nil
+		case *ast.HTMLBlock, *ast.RawHTML:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			write(string(n.Segment.Value(source)))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				words.WriteByte(' ')
+				plain.WriteByte('\n')
+			}
+		case *ast.String:
+			write(html.UnescapeString(string(n.Value)))
+		}
+		return ast.WalkContinue, nil
+	})
+
+	metrics.words = countWords(words.String())
+	metrics.plainText = normalizePlainText(plain.String())
+	return metrics
+}
+
+// normalizePlainText trims trailing spaces and collapses runs of blank lines into one
+func normalizePlainText(text string) string {
+	lines := strings.Split(text, "\n")
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		line = strings.TrimRight(line, " \t")
+		if line == "" && (len(normalized) == 0 || normalized[len(normalized)-1] == "") {
+			continue
+		}
+		normalized = append(normalized, line)
+	}
+	return strings.TrimSpace(strings.Join(normalized, "\n"))
+}
+
+// countWords counts the whitespace-separated words containing at least one letter or digit
+func countWords(text string) int {
+	count := 0
+	for _, field := range strings.Fields(text) {
+		if strings.IndexFunc(field, func(r rune) bool { return unicode.IsLetter(r) || unicode.IsDigit(r) }) >= 0 {
+			count++
+		}
+	}
+	return count
+}
+
+// readingTime estimates the reading time in whole minutes, rounding up
+func readingTime(words, wordsPerMinute int) int {
+	if words == 0 || wordsPerMinute <= 0 {
+		return 0
+	}
+	return (words + wordsPerMinute - 1) / wordsPerMinute
+}
@@ -0,0 +1,80 @@
+package markdown
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldmarkParser_ParseMarkdown_Metrics(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := "---\n" +
+		"title: Frontmatter words are not counted\n" +
+		"---\n" +
+		"# Hello, World!\n\n" +
+		"This is *very* important — `inline` code counts.\n\n" +
+		"![diagram alt text](img.png)\n\n" +
+		"```go\nfunc main() { println(\"not counted\") }\n```\n\n" +
+		"    indented code is not counted either\n"
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 9, parsed.WordCount)
+	assert.Equal(t, 1, parsed.ReadingTimeMinutes)
+	assert.Equal(t, 1, parsed.ImageCount)
+	assert.Equal(t, 2, parsed.CodeBlockCount)
+}
+
+func TestGoldmarkParser_ParseMarkdown_ReadingTime(t *testing.T) {
+	markdown := strings.Repeat("word ", 450)
+
+	parsed, err := NewGoldmarkParser().ParseMarkdown(markdown)
+	assert.NoError(t, err)
+	assert.Equal(t, 450, parsed.WordCount)
+	assert.Equal(t, 3, parsed.ReadingTimeMinutes)
+
+	parsed, err = NewGoldmarkParser(WithWordsPerMinute(300)).ParseMarkdown(markdown)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, parsed.ReadingTimeMinutes)
+}
+
+func TestGoldmarkParser_ParseMarkdown_ReadingTimeOverride(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `---
+title: Test Title
+reading_time: 7
+---
+Hello, World!`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 2, parsed.WordCount)
+	assert.Equal(t, 7, parsed.ReadingTimeMinutes)
+}
+
+func TestCountWords(t *testing.T) {
+	assert.Equal(t, 0, countWords(""))
+	assert.Equal(t, 3, countWords("one — two,  three !"))
+}
+
+func TestGoldmarkParser_ParseMarkdown_PlainText(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := "---\n" +
+		"title: Plain\n" +
+		"---\n" +
+		"# Hello, *World*!\n\n" +
+		"First line\nsecond line with a [link](https://buyallmemes.com).\n\n" +
+		"- one\n- two\n\n" +
+		"```go\nfunc main() {}\n```\n"
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello, World!\n\nFirst line\nsecond line with a link.\n\none\n\ntwo\n\nfunc main() {}", parsed.PlainText)
+}
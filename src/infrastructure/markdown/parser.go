@@ -2,14 +2,19 @@ package markdown
 
 import (
 	"bytes"
+	"context"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"buyallmemes.com/blog-api/src/domain/blog"
 	"github.com/gosimple/slug"
+	"github.com/microcosm-cc/bluemonday"
 	"github.com/yuin/goldmark"
 	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer/html"
+	"github.com/yuin/goldmark/text"
 	"go.abhg.dev/goldmark/frontmatter"
 )
 
@@ -20,68 +25,219 @@ var (
 	ErrFrontmatterDecoding = errors.New("frontmatter decoding failed")
 )
 
-// Frontmatter metadata structure
-type frontmatterMeta struct {
-	Title string `yaml:"title"`
-	Date  string `yaml:"date"`
-}
-
 // GoldmarkParser implements the MarkdownParser interface using the Goldmark library
 type GoldmarkParser struct {
-	markdown goldmark.Markdown
-	slugify  func(string) string
+	markdown       goldmark.Markdown
+	slugify        func(string) string
+	wordsPerMinute int
+	highlighting   HighlightConfig
+
+	syntaxExtensions []Extension
+	diagramRenderer  DiagramRenderer
+	admonitionTypes  []string
+	shortcodes       map[string]Shortcode
+	validationMode   ValidationMode
+	trustLevel       TrustLevel
+	sanitizer        *bluemonday.Policy
+	diagramSanitizer *bluemonday.Policy
+}
+
+// Option configures a GoldmarkParser
+type Option func(*GoldmarkParser)
+
+// WithWordsPerMinute sets the reading speed used to estimate reading time
+func WithWordsPerMinute(wordsPerMinute int) Option {
+	return func(p *GoldmarkParser) {
+		if wordsPerMinute > 0 {
+			p.wordsPerMinute = wordsPerMinute
+		}
+	}
 }
 
 // NewGoldmarkParser creates a new GoldmarkParser instance
-func NewGoldmarkParser() *GoldmarkParser {
-	return &GoldmarkParser{
-		markdown: goldmark.New(
-			goldmark.WithExtensions(
-				&frontmatter.Extender{},
-			),
+func NewGoldmarkParser(opts ...Option) *GoldmarkParser {
+	p := &GoldmarkParser{
+		slugify:        slug.Make,
+		wordsPerMinute: DefaultWordsPerMinute,
+		highlighting:   DefaultHighlightConfig(),
+
+		syntaxExtensions: DefaultExtensions(),
+		admonitionTypes:  DefaultAdmonitionTypes(),
+		validationMode:   ValidationWarn,
+		trustLevel:       TrustStrict,
+		diagramSanitizer: newDiagramSanitizer(),
+	}
+	p.shortcodes = map[string]Shortcode{}
+	for _, shortcode := range []Shortcode{
+		YouTubeShortcode{},
+		GistShortcode{},
+		TweetShortcode{},
+		&IncludeShortcode{renderCode: p.renderCode},
+	} {
+		p.shortcodes[shortcode.Name()] = shortcode
+	}
+
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	p.markdown = goldmark.New(
+		goldmark.WithExtensions(p.extensions()...),
+		goldmark.WithParserOptions(
+			parser.WithAutoHeadingID(),
 		),
-		slugify: slug.Make,
+		// Raw HTML is rendered as is and then filtered by the sanitizer of the trust level
+		goldmark.WithRendererOptions(
+			html.WithUnsafe(),
+		),
+	)
+	p.sanitizer = newSanitizer(p.trustLevel)
+
+	return p
+}
+
+// extensions returns the goldmark extensions enabled by the parser configuration
+func (p *GoldmarkParser) extensions() []goldmark.Extender {
+	extensions := []goldmark.Extender{
+		&frontmatter.Extender{},
+	}
+
+	for _, ext := range p.syntaxExtensions {
+		if extender, ok := extenders[ext]; ok {
+			extensions = append(extensions, extender(p))
+		}
+	}
+
+	if p.highlighting.Enabled {
+		extensions = append(extensions, p.highlighting.extension())
+	}
+
+	return extensions
+}
+
+// renderCode renders code as a fenced code block
+func (p *GoldmarkParser) renderCode(code, language string) (string, error) {
+	var buf bytes.Buffer
+	if err := p.markdown.Convert([]byte(fencedCodeBlock(code, language)), &buf); err != nil {
+		return "", fmt.Errorf("%w: %v", ErrMarkdownConversion, err)
 	}
+	return buf.String(), nil
 }
 
-// ParseMarkdown parses markdown content and returns parsed markdown data
+// ParseMarkdown parses markdown content that does not belong to a post source
 func (p *GoldmarkParser) ParseMarkdown(source string) (blog.ParsedMarkdown, error) {
+	return p.ParseDocument(context.Background(), blog.Document{Content: source})
+}
+
+// ParseDocument parses a markdown document and returns parsed markdown data
+func (p *GoldmarkParser) ParseDocument(ctx context.Context, document blog.Document) (blog.ParsedMarkdown, error) {
 	// Validate input
-	if strings.TrimSpace(source) == "" {
+	if strings.TrimSpace(document.Content) == "" {
 		return blog.ParsedMarkdown{}, ErrEmptyMarkdown
 	}
 
 	var buf bytes.Buffer
-	context := parser.NewContext()
-	withContext := parser.WithContext(context)
+	src := []byte(document.Content)
+	context := parser.NewContext(parser.WithIDs(newHeadingIDs(p.slugify)))
+	context.Set(documentKey, parsedDocument{ctx: ctx, document: document})
 
-	// Convert markdown to HTML
-	err := p.markdown.Convert([]byte(source), &buf, withContext)
-	if err != nil {
+	// Parse markdown, failing on errors such as unknown shortcodes, and render the document to HTML
+	doc := p.markdown.Parser().Parse(text.NewReader(src), parser.WithContext(context))
+	if err := contextError(context); err != nil {
+		return blog.ParsedMarkdown{}, err
+	}
+	if err := p.markdown.Renderer().Render(&buf, src, doc); err != nil {
 		return blog.ParsedMarkdown{}, fmt.Errorf("%w: %v", ErrMarkdownConversion, err)
 	}
 
-	// Initialize result with HTML content
+	// Initialize result with HTML content and document metrics
+	metrics := collectMetrics(doc, src)
 	result := blog.ParsedMarkdown{
-		Content: buf.String(),
+		Content:            expandPlaceholders(context, p.sanitizer.Sanitize(buf.String())),
+		TableOfContents:    buildTableOfContents(doc, src),
+		WordCount:          metrics.words,
+		ReadingTimeMinutes: readingTime(metrics.words, p.wordsPerMinute),
+		ImageCount:         metrics.images,
+		CodeBlockCount:     metrics.codeBlocks,
+		PlainText:          metrics.plainText,
+		Warnings:           contextWarnings(context),
 	}
 
-	// Extract and process frontmatter
-	if d := frontmatter.Get(context); d != nil {
-		meta := frontmatterMeta{}
-
-		if err := d.Decode(&meta); err != nil {
+	// Extract, validate and process frontmatter. Values of the wrong type are field errors like the others.
+	meta := frontmatterMeta{}
+	raw := map[string]any{}
+	var fieldErrors []*FieldError
+	d := frontmatter.Get(context)
+	if d != nil {
+		if err := d.Decode(&raw); err != nil {
 			// Return partial result with content but no metadata
-			return blog.ParsedMarkdown{Content: result.Content}, fmt.Errorf("%w: %v", ErrFrontmatterDecoding, err)
+			if document.Filename != "" {
+				return result, fmt.Errorf("%w: %s: %v", ErrFrontmatterDecoding, document.Filename, err)
+			}
+			return result, fmt.Errorf("%w: %v", ErrFrontmatterDecoding, err)
+		}
+		meta, fieldErrors = decodeFrontmatter(document.Filename, d.Decode)
+	}
+
+	// Posts are validated even without frontmatter, snippets without a filename only when they have one.
+	// Fields of the wrong type are reported once, not again as missing or invalid.
+	if d != nil || document.Filename != "" {
+		keys := make([]string, 0, len(raw))
+		for key := range raw {
+			keys = append(keys, key)
+		}
+		typeErrors := slices.Clone(fieldErrors)
+		for _, fieldError := range validateFrontmatter(document.Filename, meta, keys) {
+			if !slices.ContainsFunc(typeErrors, func(typeError *FieldError) bool { return typeError.Field == fieldError.Field }) {
+				fieldErrors = append(fieldErrors, fieldError)
+			}
+		}
+	}
+	if len(fieldErrors) > 0 {
+		if p.validationMode == ValidationFail {
+			errs := make([]error, 0, len(fieldErrors))
+			for _, fieldError := range fieldErrors {
+				errs = append(errs, fieldError)
+			}
+			return result, errors.Join(errs...)
 		}
 
-		// Set metadata fields
-		result.Title = meta.Title
-		result.Date = meta.Date
+		// Warnings are listed per document, the filename is left out
+		for _, fieldError := range fieldErrors {
+			warning := *fieldError
+			warning.Filename = ""
+			result.Warnings = append(result.Warnings, warning.Error())
+		}
+	}
 
-		// Generate anchor from title if available
-		if result.Title != "" {
-			result.Anchor = p.slugify(result.Title)
+	// Set metadata fields, invalid dates have been reported above
+	result.Title = meta.Title
+	result.Date = meta.Date
+	if updatedAt, err := blog.ParseDate(meta.Updated); err == nil && meta.Updated != "" {
+		result.UpdatedAt = updatedAt
+	}
+	result.Description = meta.Description
+	result.Tags = meta.Tags
+	result.Author = meta.Author
+	result.CoverImage = meta.CoverImage
+	result.CanonicalURL = meta.CanonicalURL
+	result.Lang = meta.Lang
+	result.Draft = meta.Draft
+	result.Unlisted = meta.Unlisted
+	if meta.ReadingTime > 0 {
+		result.ReadingTimeMinutes = meta.ReadingTime
+	}
+
+	// Use the explicit slug as anchor, otherwise generate it from the title if available
+	switch {
+	case meta.Slug != "" && slugPattern.MatchString(meta.Slug):
+		result.Anchor = meta.Slug
+	case result.Title != "":
+		result.Anchor = p.slugify(result.Title)
+	}
+	for _, alias := range meta.Aliases {
+		if slugPattern.MatchString(alias) && alias != result.Anchor && !slices.Contains(result.Aliases, alias) {
+			result.Aliases = append(result.Aliases, alias)
 		}
 	}
 
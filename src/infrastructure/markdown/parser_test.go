@@ -2,6 +2,7 @@ package markdown
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -57,7 +58,7 @@ This is a paragraph with **bold** and *italic* text.
 	parsed, err := parser.ParseMarkdown(markdown)
 
 	assert.NoError(t, err)
-	assert.Contains(t, parsed.Content, "<h1>Heading</h1>")
+	assert.Contains(t, parsed.Content, `<h1 id="heading">Heading</h1>`)
 	assert.Contains(t, parsed.Content, "<p>This is a paragraph with <strong>bold</strong> and <em>italic</em> text.</p>")
 	assert.Contains(t, parsed.Content, "<ul>")
 	assert.Contains(t, parsed.Content, "<li>List item 1</li>")
@@ -66,3 +67,40 @@ This is a paragraph with **bold** and *italic* text.
 	assert.Empty(t, parsed.Date)
 	assert.Empty(t, parsed.Anchor)
 }
+
+func TestGoldmarkParser_ParseMarkdown_WithPublishingMetadata(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `---
+title: Test Title
+date: 16.05.2024
+updated: 01.06.2024
+draft: true
+unlisted: true
+---
+Hello, World!`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC), parsed.UpdatedAt)
+	assert.True(t, parsed.Draft)
+	assert.True(t, parsed.Unlisted)
+}
+
+func TestGoldmarkParser_ParseMarkdown_WithInvalidUpdatedDate(t *testing.T) {
+	parser := NewGoldmarkParser(WithValidationMode(ValidationFail))
+
+	markdown := `---
+title: Test Title
+date: 16.05.2024
+updated: next week
+---
+Hello, World!`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.ErrorIs(t, err, ErrInvalidFrontmatter)
+	assert.Equal(t, "<p>Hello, World!</p>\n", parsed.Content)
+	assert.Empty(t, parsed.Title)
+}
@@ -0,0 +1,172 @@
+package markdown
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/microcosm-cc/bluemonday"
+)
+
+// TrustLevel controls how much of the rendered HTML of a content source is kept
+type TrustLevel string
+
+// Trust levels
+const (
+	// TrustStrict keeps the markup produced by markdown and our extensions only,
+	// for content from external contributors
+	TrustStrict TrustLevel = "strict"
+	// TrustTrusted additionally keeps inline styles, iframes of known embed hosts and raw layout HTML,
+	// for content from repositories controlled by the blog owner
+	TrustTrusted TrustLevel = "trusted"
+)
+
+// ErrUnknownTrustLevel is returned when a trust level name is not supported
+var ErrUnknownTrustLevel = errors.New("unknown trust level")
+
+// classPattern matches the class lists emitted by goldmark, chroma and our extensions
+var classPattern = regexp.MustCompile(`^[a-zA-Z0-9\-_ ]+$`)
+
+// svgElements lists the SVG elements produced by diagram renderers
+var svgElements = []string{
+	"svg", "g", "path", "rect", "circle", "ellipse", "line", "polyline", "polygon",
+	"text", "tspan", "defs", "marker", "title", "desc", "clippath", "lineargradient", "stop",
+}
+
+// svgAttributes lists the presentation attributes of SVG elements
+var svgAttributes = []string{
+	"viewbox", "width", "height", "x", "y", "x1", "y1", "x2", "y2", "cx", "cy", "r", "rx", "ry",
+	"d", "points", "transform", "fill", "fill-opacity", "fill-rule", "stroke", "stroke-width",
+	"stroke-opacity", "stroke-dasharray", "stroke-linecap", "stroke-linejoin", "opacity",
+	"font-family", "font-size", "font-weight", "font-style", "text-anchor", "dominant-baseline",
+	"alignment-baseline", "marker-start", "marker-end", "markerwidth", "markerheight",
+	"markerunits", "refx", "refy", "orient", "offset", "stop-color", "stop-opacity",
+	"gradientunits", "clip-path", "clippathunits", "preserveaspectratio", "xmlns", "version",
+	"role", "aria-roledescription", "dx", "dy",
+}
+
+// diagramLabelElements lists the HTML elements of the labels mermaid draws inside <foreignObject>
+var diagramLabelElements = []string{"div", "span", "p", "br", "b", "i", "strong", "em", "code"}
+
+// diagramStyleProperties lists the CSS properties of the inline styles of diagram shapes and labels
+var diagramStyleProperties = []string{
+	"fill", "fill-opacity", "stroke", "stroke-width", "stroke-dasharray", "stroke-opacity", "opacity",
+	"color", "background-color", "font-family", "font-size", "font-weight", "font-style", "text-align",
+	"display", "white-space", "line-height", "max-width", "width", "height", "padding",
+}
+
+// diagramIDPattern matches the IDs diagram stylesheets and markers refer to
+var diagramIDPattern = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9\-_:.]*$`)
+
+// diagramStyleValuePattern matches inline style values without quotes, colons or slashes, so no external URLs
+var diagramStyleValuePattern = regexp.MustCompile(`^[a-zA-Z0-9#.,%() \-]+$`)
+
+// diagramStylesheetPattern matches the stylesheets embedded in sanitized diagram SVGs
+var diagramStylesheetPattern = regexp.MustCompile(`(?is)<style>(.*?)</style>`)
+
+// unsafeCSSPattern matches CSS that could inject markup, load resources or cover the page.
+// Browsers parse the content of <style> inside <svg> as markup, so it must not contain a tag.
+var unsafeCSSPattern = regexp.MustCompile(`(?i)<|@import|url\s*\(|expression\s*\(|javascript:|position\s*:\s*fixed`)
+
+// youtubeEmbedPattern matches the iframe sources of the youtube shortcode, kept at every trust level
+var youtubeEmbedPattern = regexp.MustCompile(`^https://www\.youtube-nocookie\.com/embed/[A-Za-z0-9_-]{11}$`)
+
+// trustedIframePattern matches the iframe sources of the embed hosts kept at the trusted level
+var trustedIframePattern = regexp.MustCompile(
+	`^https://(www\.youtube-nocookie\.com|www\.youtube\.com|player\.vimeo\.com|codepen\.io|codesandbox\.io)/`,
+)
+
+// codeStyleProperties lists the CSS properties chroma uses for inline highlighting styles
+var codeStyleProperties = []string{
+	"color", "background-color", "font-weight", "font-style", "text-decoration",
+	"display", "width", "padding", "margin", "border", "white-space", "user-select", "tab-size",
+}
+
+// ParseTrustLevel converts a trust level name into a TrustLevel
+func ParseTrustLevel(name string) (TrustLevel, error) {
+	switch level := TrustLevel(strings.ToLower(strings.TrimSpace(name))); level {
+	case TrustStrict, TrustTrusted:
+		return level, nil
+	case "":
+		return TrustStrict, nil
+	default:
+		return "", fmt.Errorf("%w: %s", ErrUnknownTrustLevel, name)
+	}
+}
+
+// WithTrustLevel sets the sanitization policy applied to the rendered HTML
+func WithTrustLevel(level TrustLevel) Option {
+	return func(p *GoldmarkParser) {
+		p.trustLevel = level
+	}
+}
+
+// newSanitizer creates the HTML sanitization policy for the given trust level
+func newSanitizer(level TrustLevel) *bluemonday.Policy {
+	policy := bluemonday.UGCPolicy()
+
+	// In-page links such as footnotes and heading anchors stay followable
+	policy.RequireNoFollowOnLinks(false)
+	policy.RequireNoFollowOnFullyQualifiedLinks(true)
+
+	// Classes and IDs of highlighted code, footnotes, math, diagrams and callouts
+	policy.AllowAttrs("class").Matching(classPattern).Globally()
+	policy.AllowAttrs("role").Matching(regexp.MustCompile(`^(note|doc-endnotes|doc-noteref|doc-backlink|img)$`)).Globally()
+	policy.AllowAttrs("type").Matching(regexp.MustCompile(`^checkbox$`)).OnElements("input")
+	policy.AllowAttrs("checked", "disabled").Matching(regexp.MustCompile(`^$`)).OnElements("input")
+	policy.AllowStyles(codeStyleProperties...).OnElements("pre", "code", "span")
+
+	// Videos embedded by the youtube shortcode
+	policy.AllowAttrs("src").Matching(youtubeEmbedPattern).OnElements("iframe")
+	policy.AllowAttrs("title", "loading", "allowfullscreen").OnElements("iframe")
+
+	// Inline SVG diagrams
+	policy.AllowElements(svgElements...)
+	policy.AllowNoAttrs().OnElements(svgElements...)
+	policy.AllowAttrs(svgAttributes...).OnElements(svgElements...)
+
+	if level == TrustTrusted {
+		policy.RequireNoFollowOnFullyQualifiedLinks(false)
+		policy.AllowAttrs("style").Globally()
+		policy.AllowElements("div", "span", "center")
+		policy.AllowAttrs("src").Matching(trustedIframePattern).OnElements("iframe")
+		policy.AllowAttrs("width", "height", "allow", "allowfullscreen", "frameborder", "title").OnElements("iframe")
+		policy.AllowAttrs("controls", "src", "poster", "width", "height").OnElements("video", "audio")
+		policy.AllowElements("source")
+		policy.AllowAttrs("src", "type").OnElements("source")
+	}
+
+	return policy
+}
+
+// newDiagramSanitizer creates the policy of rendered diagram SVGs at every trust level.
+// Unlike the policy of the document, it keeps the stylesheet and the HTML labels of the SVG.
+func newDiagramSanitizer() *bluemonday.Policy {
+	policy := bluemonday.NewPolicy()
+
+	elements := append(append([]string{"style", "foreignobject"}, svgElements...), diagramLabelElements...)
+	policy.AllowElements(elements...)
+	policy.AllowNoAttrs().OnElements(elements...)
+	policy.AllowAttrs(svgAttributes...).OnElements(svgElements...)
+	policy.AllowAttrs("width", "height", "x", "y").OnElements("foreignobject")
+	policy.AllowAttrs("xmlns").Matching(regexp.MustCompile(`^http://www\.w3\.org/1999/xhtml$`)).OnElements("div")
+	policy.AllowAttrs("class").Matching(classPattern).Globally()
+	policy.AllowAttrs("id").Matching(diagramIDPattern).Globally()
+	policy.AllowStyles(diagramStyleProperties...).Matching(diagramStyleValuePattern).Globally()
+
+	// Keep the CSS inside <style>, checked by sanitizeDiagram
+	policy.AllowUnsafe(true)
+
+	return policy
+}
+
+// sanitizeDiagram sanitizes a rendered diagram SVG and drops the stylesheets with unsafe CSS
+func sanitizeDiagram(policy *bluemonday.Policy, svg string) string {
+	return diagramStylesheetPattern.ReplaceAllStringFunc(policy.Sanitize(svg), func(stylesheet string) string {
+		if unsafeCSSPattern.MatchString(diagramStylesheetPattern.FindStringSubmatch(stylesheet)[1]) {
+			return ""
+		}
+		return stylesheet
+	})
+}
@@ -0,0 +1,93 @@
+package markdown
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const untrustedMarkdown = `# Hello
+
+<script>alert("xss")</script>
+
+<img src="x" onerror="alert('xss')">
+
+[click](javascript:alert('xss'))
+
+<iframe src="https://www.youtube-nocookie.com/embed/id"></iframe>
+
+<iframe src="https://evil.example/phishing"></iframe>
+
+<style>body{display:none}</style>
+
+<p style="position:fixed">overlay</p>
+`
+
+func TestGoldmarkParser_ParseMarkdown_SanitizesStrict(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown(untrustedMarkdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<h1 id="hello">Hello</h1>`)
+	assert.NotContains(t, parsed.Content, "<script")
+	assert.NotContains(t, parsed.Content, "onerror")
+	assert.NotContains(t, parsed.Content, "javascript:")
+	assert.NotContains(t, parsed.Content, "<iframe")
+	assert.NotContains(t, parsed.Content, "evil.example")
+	assert.NotContains(t, parsed.Content, "<style")
+	assert.NotContains(t, parsed.Content, "display:none")
+	assert.NotContains(t, parsed.Content, "position:fixed")
+}
+
+func TestGoldmarkParser_ParseMarkdown_SanitizesTrusted(t *testing.T) {
+	parser := NewGoldmarkParser(WithTrustLevel(TrustTrusted))
+
+	parsed, err := parser.ParseMarkdown(untrustedMarkdown)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, parsed.Content, "<script")
+	assert.NotContains(t, parsed.Content, "onerror")
+	assert.NotContains(t, parsed.Content, "javascript:")
+	assert.Contains(t, parsed.Content, `<iframe src="https://www.youtube-nocookie.com/embed/id"></iframe>`)
+	assert.Contains(t, parsed.Content, `<p style="position:fixed">overlay</p>`)
+
+	// Only iframes of known embed hosts and no stylesheets outside of diagrams
+	assert.NotContains(t, parsed.Content, "evil.example")
+	assert.NotContains(t, parsed.Content, "<style")
+	assert.NotContains(t, parsed.Content, "display:none")
+}
+
+func TestGoldmarkParser_ParseMarkdown_SanitizerKeepsExtensionMarkup(t *testing.T) {
+	parser := NewGoldmarkParser(WithDiagramRenderer(&StubDiagramRenderer{}))
+
+	markdown := "> [!NOTE]\n> Read this[^1].\n\n" +
+		"- [x] done\n\n" +
+		"$x^2$\n\n" +
+		"```mermaid\ngraph TD;\n```\n\n" +
+		"```go\nfunc main() {}\n```\n\n" +
+		"[^1]: Footnote.\n"
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<aside class="admonition admonition-note" role="note">`)
+	assert.Contains(t, parsed.Content, `<sup id="fnref:1"><a href="#fn:1" class="footnote-ref" role="doc-noteref">1</a></sup>`)
+	assert.Contains(t, parsed.Content, `<input checked="" disabled="" type="checkbox">`)
+	assert.Contains(t, parsed.Content, `<span class="math math-inline">`)
+	assert.Contains(t, parsed.Content, `<svg aria-roledescription="mermaid"><g><text>`)
+	assert.Contains(t, parsed.Content, `<span class="kd">func</span>`)
+}
+
+func TestParseTrustLevel(t *testing.T) {
+	level, err := ParseTrustLevel(" Trusted ")
+	assert.NoError(t, err)
+	assert.Equal(t, TrustTrusted, level)
+
+	level, err = ParseTrustLevel("")
+	assert.NoError(t, err)
+	assert.Equal(t, TrustStrict, level)
+
+	_, err = ParseTrustLevel("admin")
+	assert.ErrorIs(t, err, ErrUnknownTrustLevel)
+}
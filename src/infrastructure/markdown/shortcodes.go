@@ -0,0 +1,312 @@
+package markdown
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/renderer"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// ExtensionShortcodes expands {{< name args >}} lines with the registered shortcodes.
+// Like in Hugo, {{</* name args */>}} is written as the literal text {{< name args >}}.
+const ExtensionShortcodes Extension = "shortcodes"
+
+// KindShortcodeBlock is the node kind of shortcode blocks
+var KindShortcodeBlock = ast.NewNodeKind("ShortcodeBlock")
+
+// Shortcode errors
+var (
+	ErrUnknownShortcode   = errors.New("unknown shortcode")
+	ErrShortcodeRendering = errors.New("shortcode rendering failed")
+	ErrShortcodeSyntax    = errors.New("invalid shortcode syntax")
+)
+
+// Shortcode expands a shortcode call into HTML
+type Shortcode interface {
+	// Name returns the name the shortcode is called by
+	Name() string
+	// Render returns the HTML the call expands to
+	Render(ctx context.Context, call ShortcodeCall) (string, error)
+}
+
+// ShortcodeCall holds the arguments of a shortcode call and the document it appears in
+type ShortcodeCall struct {
+	// Args are the positional arguments, Params the key="value" arguments
+	Args   []string
+	Params map[string]string
+
+	Document blog.Document
+}
+
+// Arg returns the positional argument at index i, or an empty string
+func (c ShortcodeCall) Arg(i int) string {
+	if i < len(c.Args) {
+		return c.Args[i]
+	}
+	return ""
+}
+
+// ShortcodeBlock is a {{< name args >}} line, expanded at parse time.
+// The expanded HTML is sanitized with the rest of the document, like raw HTML written by the author.
+type ShortcodeBlock struct {
+	ast.BaseBlock
+	Name   string
+	Args   []string
+	Params map[string]string
+	Offset int
+	HTML   string
+}
+
+// Kind implements ast.Node
+func (n *ShortcodeBlock) Kind() ast.NodeKind {
+	return KindShortcodeBlock
+}
+
+// IsRaw implements ast.Node
+func (n *ShortcodeBlock) IsRaw() bool {
+	return true
+}
+
+// Dump implements ast.Node
+func (n *ShortcodeBlock) Dump(source []byte, level int) {
+	ast.DumpHelper(n, source, level, map[string]string{"Name": n.Name}, nil)
+}
+
+// WithShortcodes registers shortcodes, replacing registered shortcodes of the same name
+func WithShortcodes(shortcodes ...Shortcode) Option {
+	return func(p *GoldmarkParser) {
+		for _, shortcode := range shortcodes {
+			p.shortcodes[shortcode.Name()] = shortcode
+		}
+	}
+}
+
+// documentKey stores the context and document being parsed
+var documentKey = parser.NewContextKey()
+
+// parsedDocument is the document being parsed together with the context of the parse
+type parsedDocument struct {
+	ctx      context.Context
+	document blog.Document
+}
+
+// contextDocument returns the document being parsed, with a background context outside of ParseDocument
+func contextDocument(pc parser.Context) parsedDocument {
+	parsed, ok := pc.Get(documentKey).(parsedDocument)
+	if !ok {
+		parsed.ctx = context.Background()
+	}
+	return parsed
+}
+
+// shortcodeExtension registers the shortcode parser, transformer and renderer
+type shortcodeExtension struct {
+	shortcodes map[string]Shortcode
+}
+
+// Extend implements goldmark.Extender
+func (e *shortcodeExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(
+		parser.WithBlockParsers(util.Prioritized(&shortcodeParser{}, 90)),
+		parser.WithInlineParsers(util.Prioritized(&shortcodeEscapeParser{}, 150)),
+		parser.WithASTTransformers(util.Prioritized(&shortcodeTransformer{shortcodes: e.shortcodes}, 100)),
+	)
+	m.Renderer().AddOptions(
+		renderer.WithNodeRenderers(util.Prioritized(&shortcodeNodeRenderer{}, 100)),
+	)
+}
+
+// shortcodeParser parses lines consisting of a single {{< name args >}} call
+type shortcodeParser struct{}
+
+// Trigger implements parser.BlockParser
+func (b *shortcodeParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+// Open implements parser.BlockParser
+func (b *shortcodeParser) Open(_ ast.Node, reader text.Reader, pc parser.Context) (ast.Node, parser.State) {
+	line, segment := reader.PeekLine()
+	trimmed := bytes.TrimSpace(line)
+	if !bytes.HasPrefix(trimmed, []byte("{{<")) || !bytes.HasSuffix(trimmed, []byte(">}}")) ||
+		bytes.HasPrefix(trimmed, []byte("{{</*")) {
+		return nil, parser.NoChildren
+	}
+
+	node := &ShortcodeBlock{Offset: segment.Start}
+	inner := string(trimmed[3 : len(trimmed)-3])
+	name, args, params, err := parseShortcodeArgs(inner)
+	if err != nil {
+		addError(pc, fmt.Errorf("%w at %s: %v", ErrShortcodeSyntax, location(pc, reader.Source(), segment.Start), err))
+	}
+	node.Name, node.Args, node.Params = name, args, params
+
+	reader.Advance(segment.Len() - 1)
+	return node, parser.NoChildren
+}
+
+// Continue implements parser.BlockParser
+func (b *shortcodeParser) Continue(_ ast.Node, _ text.Reader, _ parser.Context) parser.State {
+	return parser.Close
+}
+
+// Close implements parser.BlockParser
+func (b *shortcodeParser) Close(_ ast.Node, _ text.Reader, _ parser.Context) {}
+
+// CanInterruptParagraph implements parser.BlockParser
+func (b *shortcodeParser) CanInterruptParagraph() bool {
+	return true
+}
+
+// CanAcceptIndentedLine implements parser.BlockParser
+func (b *shortcodeParser) CanAcceptIndentedLine() bool {
+	return false
+}
+
+// shortcodeEscapeParser parses escaped {{</* name args */>}} calls into the literal text of the call
+type shortcodeEscapeParser struct{}
+
+// Trigger implements parser.InlineParser
+func (p *shortcodeEscapeParser) Trigger() []byte {
+	return []byte{'{'}
+}
+
+// Parse implements parser.InlineParser
+func (p *shortcodeEscapeParser) Parse(_ ast.Node, block text.Reader, _ parser.Context) ast.Node {
+	line, _ := block.PeekLine()
+	if !bytes.HasPrefix(line, []byte("{{</*")) {
+		return nil
+	}
+	end := bytes.Index(line, []byte("*/>}}"))
+	if end < 0 {
+		return nil
+	}
+
+	literal := ast.NewString([]byte("{{<" + string(line[5:end]) + ">}}"))
+	// Raw strings are escaped, but not unescaped like markdown text
+	literal.SetRaw(true)
+	block.Advance(end + 5)
+	return literal
+}
+
+// parseShortcodeArgs splits the inside of a shortcode call into its name,
+// positional arguments and key="value" parameters. Values may be double-quoted.
+func parseShortcodeArgs(inner string) (string, []string, map[string]string, error) {
+	var tokens []string
+	var token strings.Builder
+	inToken, quoted, escaped := false, false, false
+
+	for _, r := range inner {
+		switch {
+		case escaped:
+			token.WriteRune(r)
+			escaped = false
+		case quoted && r == '\\':
+			escaped = true
+		case r == '"':
+			quoted = !quoted
+			inToken = true
+		case !quoted && (r == ' ' || r == '\t'):
+			if inToken {
+				tokens = append(tokens, token.String())
+				token.Reset()
+				inToken = false
+			}
+		default:
+			token.WriteRune(r)
+			inToken = true
+		}
+	}
+	if quoted {
+		return "", nil, nil, errors.New("unterminated quoted value")
+	}
+	if inToken {
+		tokens = append(tokens, token.String())
+	}
+	if len(tokens) == 0 || tokens[0] == "" {
+		return "", nil, nil, errors.New("missing shortcode name")
+	}
+
+	var args []string
+	params := make(map[string]string)
+	for _, token := range tokens[1:] {
+		if key, value, ok := strings.Cut(token, "="); ok && key != "" {
+			params[key] = value
+			continue
+		}
+		args = append(args, token)
+	}
+	return tokens[0], args, params, nil
+}
+
+// shortcodeTransformer expands the shortcode calls of a document
+type shortcodeTransformer struct {
+	shortcodes map[string]Shortcode
+}
+
+// Transform implements parser.ASTTransformer
+func (t *shortcodeTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	source := reader.Source()
+	parsed := contextDocument(pc)
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		call, ok := node.(*ShortcodeBlock)
+		if !ok || !entering || call.Name == "" {
+			return ast.WalkContinue, nil
+		}
+
+		shortcode, ok := t.shortcodes[call.Name]
+		if !ok {
+			addError(pc, fmt.Errorf("%w %q at %s", ErrUnknownShortcode, call.Name, location(pc, source, call.Offset)))
+			return ast.WalkSkipChildren, nil
+		}
+
+		output, err := shortcode.Render(parsed.ctx, ShortcodeCall{
+			Args:     call.Args,
+			Params:   call.Params,
+			Document: parsed.document,
+		})
+		if err != nil {
+			addError(pc, fmt.Errorf("%w: %s at %s: %v", ErrShortcodeRendering, call.Name, location(pc, source, call.Offset), err))
+			return ast.WalkSkipChildren, nil
+		}
+		call.HTML = output
+		return ast.WalkSkipChildren, nil
+	})
+}
+
+// location formats a source offset as file:line, or line N when the document has no name
+func location(pc parser.Context, source []byte, offset int) string {
+	parsed, _ := pc.Get(documentKey).(parsedDocument)
+	if parsed.document.Filename == "" {
+		return fmt.Sprintf("line %d", lineAt(source, offset))
+	}
+	return fmt.Sprintf("%s:%d", parsed.document.Filename, lineAt(source, offset))
+}
+
+// shortcodeNodeRenderer writes the HTML of expanded shortcodes
+type shortcodeNodeRenderer struct{}
+
+// RegisterFuncs implements renderer.NodeRenderer
+func (r *shortcodeNodeRenderer) RegisterFuncs(reg renderer.NodeRendererFuncRegisterer) {
+	reg.Register(KindShortcodeBlock, r.render)
+}
+
+// render renders a shortcode call
+func (r *shortcodeNodeRenderer) render(w util.BufWriter, _ []byte, node ast.Node, entering bool) (ast.WalkStatus, error) {
+	if entering {
+		if n := node.(*ShortcodeBlock); n.HTML != "" {
+			_, _ = w.WriteString(strings.TrimRight(n.HTML, "\n") + "\n")
+		}
+	}
+	return ast.WalkSkipChildren, nil
+}
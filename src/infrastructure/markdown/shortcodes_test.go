@@ -0,0 +1,230 @@
+package markdown
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubFileReader is a stub implementation of the FileReader interface
+type StubFileReader struct {
+	files map[string]string
+}
+
+func (s *StubFileReader) ReadFile(_ context.Context, path string) ([]byte, error) {
+	content, ok := s.files[path]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, path)
+	}
+	return []byte(content), nil
+}
+
+// StubShortcode is a stub implementation of the Shortcode interface
+type StubShortcode struct{}
+
+func (StubShortcode) Name() string {
+	return "greet"
+}
+
+func (StubShortcode) Render(_ context.Context, call ShortcodeCall) (string, error) {
+	return fmt.Sprintf("<p>Hello, %s from %s!</p>", call.Params["name"], call.Document.Filename), nil
+}
+
+// UnsafeShortcode is a shortcode emitting markup the sanitizer drops
+type UnsafeShortcode struct{}
+
+func (UnsafeShortcode) Name() string {
+	return "unsafe"
+}
+
+func (UnsafeShortcode) Render(_ context.Context, _ ShortcodeCall) (string, error) {
+	return `<p onclick="alert(1)">Hi</p><script>alert(1)</script><iframe src="https://evil.example"></iframe>`, nil
+}
+
+const exampleGo = `package main
+
+import "fmt"
+
+func main() {
+	fmt.Println("hello")
+}
+`
+
+func parseDocument(parser *GoldmarkParser, content string) (blog.ParsedMarkdown, error) {
+	return parser.ParseDocument(context.Background(), blog.Document{
+		Filename: "20240516-post.md",
+		Content:  content,
+		Files:    &StubFileReader{files: map[string]string{"examples/main.go": exampleGo}},
+	})
+}
+
+func TestParseShortcodeArgs(t *testing.T) {
+	name, args, params, err := parseShortcodeArgs(` include "examples/my file.go" lines="10-30" lang=go `)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "include", name)
+	assert.Equal(t, []string{"examples/my file.go"}, args)
+	assert.Equal(t, map[string]string{"lines": "10-30", "lang": "go"}, params)
+
+	_, _, _, err = parseShortcodeArgs(`include "examples/main.go`)
+	assert.Error(t, err)
+
+	_, _, _, err = parseShortcodeArgs(" ")
+	assert.Error(t, err)
+}
+
+func TestGoldmarkParser_ParseDocument_Embeds(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parseDocument(parser, "# Embeds\n\n"+
+		"{{< youtube dQw4w9WgXcQ >}}\n\n"+
+		"{{< gist buyallmemes 0123abcd >}}\n\n"+
+		"{{< tweet buyallmemes 1790000000000000000 >}}\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<figure class="embed embed-youtube"><iframe src="https://www.youtube-nocookie.com/embed/dQw4w9WgXcQ" title="YouTube video" loading="lazy" allowfullscreen="">`)
+	assert.Contains(t, parsed.Content, `<a href="https://gist.github.com/buyallmemes/0123abcd" rel="nofollow">`)
+	assert.Contains(t, parsed.Content, `<a href="https://twitter.com/buyallmemes/status/1790000000000000000" rel="nofollow">`)
+	assert.NotContains(t, parsed.Content, "{{&lt;")
+}
+
+func TestGoldmarkParser_ParseDocument_InvalidEmbed(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	_, err := parseDocument(parser, "# Embeds\n\n{{< youtube \"><script>\" >}}\n")
+
+	assert.ErrorIs(t, err, ErrShortcodeRendering)
+	assert.Contains(t, err.Error(), "20240516-post.md:3")
+}
+
+func TestGoldmarkParser_ParseDocument_UnknownShortcode(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	_, err := parseDocument(parser, "# Post\n\nSome text.\n\n{{< vimeo 123 >}}\n")
+
+	assert.ErrorIs(t, err, ErrUnknownShortcode)
+	assert.EqualError(t, err, `unknown shortcode "vimeo" at 20240516-post.md:5`)
+}
+
+func TestGoldmarkParser_ParseDocument_Include(t *testing.T) {
+	parser := NewGoldmarkParser(WithHighlighting(HighlightConfig{}))
+
+	parsed, err := parseDocument(parser, "# Include\n\n{{< include \"examples/main.go\" lines=\"5-7\" >}}\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<pre><code class="language-go">func main() {
+	fmt.Println(&#34;hello&#34;)
+}
+</code></pre>`)
+	assert.NotContains(t, parsed.Content, "package main")
+}
+
+func TestGoldmarkParser_ParseDocument_IncludeHighlighted(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parseDocument(parser, "{{< include examples/main.go >}}\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, `<pre class="chroma">`)
+	assert.Contains(t, parsed.Content, "package")
+}
+
+func TestGoldmarkParser_ParseDocument_IncludeErrors(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	tests := []struct {
+		name     string
+		markdown string
+		expected string
+	}{
+		{name: "missing file", markdown: "{{< include examples/missing.go >}}\n", expected: "file not found"},
+		{name: "line range out of bounds", markdown: "{{< include examples/main.go lines=\"5-70\" >}}\n", expected: "out of bounds"},
+		{name: "invalid line range", markdown: "{{< include examples/main.go lines=\"five\" >}}\n", expected: "invalid line range"},
+		{name: "missing path", markdown: "{{< include >}}\n", expected: "missing file path"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := parseDocument(parser, tt.markdown)
+
+			assert.ErrorIs(t, err, ErrShortcodeRendering)
+			assert.Contains(t, err.Error(), "20240516-post.md:1")
+			assert.Contains(t, err.Error(), tt.expected)
+		})
+	}
+}
+
+func TestGoldmarkParser_ParseDocument_IncludeWithoutFileReader(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	_, err := parser.ParseMarkdown("{{< include examples/main.go >}}\n")
+
+	assert.ErrorIs(t, err, ErrShortcodeRendering)
+	assert.Contains(t, err.Error(), "line 1")
+}
+
+func TestGoldmarkParser_ParseDocument_CustomShortcode(t *testing.T) {
+	parser := NewGoldmarkParser(WithShortcodes(StubShortcode{}))
+
+	parsed, err := parseDocument(parser, "{{< greet name=\"World\" >}}\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<p>Hello, World from 20240516-post.md!</p>")
+}
+
+func TestGoldmarkParser_ParseDocument_ShortcodeSanitized(t *testing.T) {
+	parser := NewGoldmarkParser(WithShortcodes(UnsafeShortcode{}))
+
+	parsed, err := parseDocument(parser, "{{< unsafe >}}\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<p>Hi</p>")
+	assert.NotContains(t, parsed.Content, "onclick")
+	assert.NotContains(t, parsed.Content, "<script")
+	assert.NotContains(t, parsed.Content, "evil.example")
+}
+
+func TestGoldmarkParser_ParseDocument_IncludeLanguage(t *testing.T) {
+	parser := NewGoldmarkParser(WithDiagramRenderer(&StubDiagramRenderer{}))
+
+	for _, lang := range []string{"mermaid", "PlantUML", "go```", "go $$"} {
+		t.Run(lang, func(t *testing.T) {
+			_, err := parseDocument(parser, fmt.Sprintf("{{< include examples/main.go lang=%q >}}\n", lang))
+
+			assert.ErrorIs(t, err, ErrShortcodeRendering)
+			assert.Contains(t, err.Error(), "invalid code language")
+		})
+	}
+}
+
+func TestGoldmarkParser_ParseDocument_ShortcodeInCode(t *testing.T) {
+	parser := NewGoldmarkParser(WithHighlighting(HighlightConfig{}))
+
+	parsed, err := parseDocument(parser, "```\n{{< vimeo 123 >}}\n```\n\nUse `{{< youtube id >}}` inline.\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "{{&lt; vimeo 123 &gt;}}")
+}
+
+func TestGoldmarkParser_ParseDocument_EscapedShortcode(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parseDocument(parser, "Embed a video with:\n\n{{</* vimeo 123 */>}}\n\nOr {{</* youtube \"id\" */>}} inline.\n")
+
+	assert.NoError(t, err)
+	assert.Contains(t, parsed.Content, "<p>{{&lt; vimeo 123 &gt;}}</p>")
+	assert.Contains(t, parsed.Content, "<p>Or {{&lt; youtube &#34;id&#34; &gt;}} inline.</p>")
+}
+
+func TestGoldmarkParser_ParseDocument_ShortcodesDisabled(t *testing.T) {
+	parser := NewGoldmarkParser(WithExtensions(ExtensionGFM))
+
+	parsed, err := parseDocument(parser, "{{< vimeo 123 >}}\n")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(parsed.Content, "<p>{{&lt; vimeo 123 &gt;}}</p>"))
+}
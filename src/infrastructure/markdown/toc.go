@@ -0,0 +1,103 @@
+package markdown
+
+import (
+	"html"
+	"strconv"
+	"strings"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+)
+
+// headingIDs generates unique, slug-based heading IDs for a single document
+type headingIDs struct {
+	slugify func(string) string
+	used    map[string]bool
+}
+
+// newHeadingIDs creates a new headingIDs instance
+func newHeadingIDs(slugify func(string) string) parser.IDs {
+	return &headingIDs{
+		slugify: slugify,
+		used:    make(map[string]bool),
+	}
+}
+
+// Generate generates a unique ID for the given heading text
+func (ids *headingIDs) Generate(value []byte, kind ast.NodeKind) []byte {
+	base := ids.slugify(string(value))
+	if base == "" {
+		base = strings.ToLower(kind.String())
+	}
+
+	id := base
+	for i := 1; ids.used[id]; i++ {
+		id = base + "-" + strconv.Itoa(i)
+	}
+	ids.used[id] = true
+
+	return []byte(id)
+}
+
+// Put marks the given ID as used
+func (ids *headingIDs) Put(value []byte) {
+	ids.used[string(value)] = true
+}
+
+// buildTableOfContents collects the document headings into a nested table of contents
+func buildTableOfContents(doc ast.Node, source []byte) []blog.TOCEntry {
+	root := &blog.TOCEntry{}
+	stack := []*blog.TOCEntry{root}
+
+	_ = ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		heading, ok := node.(*ast.Heading)
+		if !ok || !entering {
+			return ast.WalkContinue, nil
+		}
+
+		entry := blog.TOCEntry{
+			Level: heading.Level,
+			Text:  nodeText(heading, source),
+		}
+		if id, ok := heading.AttributeString("id"); ok {
+			if value, ok := id.([]byte); ok {
+				entry.ID = string(value)
+			}
+		}
+
+		// Pop entries until the top of the stack is a shallower heading (or the root)
+		for len(stack) > 1 && stack[len(stack)-1].Level >= entry.Level {
+			stack = stack[:len(stack)-1]
+		}
+		parent := stack[len(stack)-1]
+		parent.Children = append(parent.Children, entry)
+		stack = append(stack, &parent.Children[len(parent.Children)-1])
+
+		return ast.WalkSkipChildren, nil
+	})
+
+	return root.Children
+}
+
+// nodeText returns the plain text content of a node and its descendants
+func nodeText(node ast.Node, source []byte) string {
+	var b strings.Builder
+	_ = ast.Walk(node, func(child ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+		switch n := child.(type) {
+		case *ast.Text:
+			b.Write(n.Segment.Value(source))
+			if n.SoftLineBreak() || n.HardLineBreak() {
+				b.WriteByte(' ')
+			}
+		case *ast.String:
+			// Typographer substitutions are stored as HTML entities
+			b.WriteString(html.UnescapeString(string(n.Value)))
+		}
+		return ast.WalkContinue, nil
+	})
+	return strings.TrimSpace(b.String())
+}
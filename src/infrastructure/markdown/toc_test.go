@@ -0,0 +1,85 @@
+package markdown
+
+import (
+	"os"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGoldmarkParser_ParseMarkdown_TableOfContents(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	markdown := `# Testing
+
+## Unit tests
+
+### What to *mock*
+
+## Integration tests
+
+### What to *mock*
+
+# Summary
+`
+
+	parsed, err := parser.ParseMarkdown(markdown)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []blog.TOCEntry{
+		{
+			Level: 1, Text: "Testing", ID: "testing",
+			Children: []blog.TOCEntry{
+				{
+					Level: 2, Text: "Unit tests", ID: "unit-tests",
+					Children: []blog.TOCEntry{{Level: 3, Text: "What to mock", ID: "what-to-mock"}},
+				},
+				{
+					Level: 2, Text: "Integration tests", ID: "integration-tests",
+					Children: []blog.TOCEntry{{Level: 3, Text: "What to mock", ID: "what-to-mock-1"}},
+				},
+			},
+		},
+		{Level: 1, Text: "Summary", ID: "summary"},
+	}, parsed.TableOfContents)
+	assert.Contains(t, parsed.Content, `<h3 id="what-to-mock-1">What to <em>mock</em></h3>`)
+}
+
+func TestGoldmarkParser_ParseMarkdown_TableOfContents_SkippedLevels(t *testing.T) {
+	parser := NewGoldmarkParser()
+
+	parsed, err := parser.ParseMarkdown("### Deep\n\n## Shallow\n\n#### Deeper\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []blog.TOCEntry{
+		{Level: 3, Text: "Deep", ID: "deep"},
+		{
+			Level: 2, Text: "Shallow", ID: "shallow",
+			Children: []blog.TOCEntry{{Level: 4, Text: "Deeper", ID: "deeper"}},
+		},
+	}, parsed.TableOfContents)
+}
+
+func TestGoldmarkParser_ParseMarkdown_TableOfContents_UniqueIDs(t *testing.T) {
+	parser := NewGoldmarkParser()
+	source, err := os.ReadFile("../../../posts/20240516-testing-guideline.md")
+	assert.NoError(t, err)
+
+	parsed, err := parser.ParseMarkdown(string(source))
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, parsed.TableOfContents)
+
+	seen := make(map[string]bool)
+	var visit func(entries []blog.TOCEntry)
+	visit = func(entries []blog.TOCEntry) {
+		for _, entry := range entries {
+			assert.NotEmpty(t, entry.ID)
+			assert.False(t, seen[entry.ID], "duplicate heading id %q", entry.ID)
+			seen[entry.ID] = true
+			visit(entry.Children)
+		}
+	}
+	visit(parsed.TableOfContents)
+}
@@ -0,0 +1,47 @@
+package markdown
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+
+	"github.com/yuin/goldmark/parser"
+)
+
+// warningsKey stores the non-fatal problems found while parsing a document
+var warningsKey = parser.NewContextKey()
+
+// addWarning records a non-fatal problem found at the given source offset
+func addWarning(pc parser.Context, source []byte, offset int, format string, args ...any) {
+	message := fmt.Sprintf("line %d: %s", lineAt(source, offset), fmt.Sprintf(format, args...))
+
+	warnings, _ := pc.Get(warningsKey).([]string)
+	pc.Set(warningsKey, append(warnings, message))
+}
+
+// contextWarnings returns the warnings recorded in the parser context
+func contextWarnings(pc parser.Context) []string {
+	warnings, _ := pc.Get(warningsKey).([]string)
+	return warnings
+}
+
+// errorsKey stores the errors that fail the parse of a document
+var errorsKey = parser.NewContextKey()
+
+// addError records an error that fails the parse of the document
+func addError(pc parser.Context, err error) {
+	errs, _ := pc.Get(errorsKey).([]error)
+	pc.Set(errorsKey, append(errs, err))
+}
+
+// contextError returns the errors recorded in the parser context joined into one
+func contextError(pc parser.Context) error {
+	errs, _ := pc.Get(errorsKey).([]error)
+	return errors.Join(errs...)
+}
+
+// lineAt returns the 1-based line number of the given source offset
+func lineAt(source []byte, offset int) int {
+	offset = min(max(offset, 0), len(source))
+	return bytes.Count(source[:offset], []byte{'\n'}) + 1
+}
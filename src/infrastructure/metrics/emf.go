@@ -0,0 +1,107 @@
+package metrics
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"slices"
+	"sync"
+	"time"
+)
+
+// DefaultNamespace is the CloudWatch namespace of the metrics of the blog
+const DefaultNamespace = "BlogAPI"
+
+// Metric units
+const (
+	UnitCount   = "Count"
+	UnitSeconds = "Seconds"
+)
+
+// Metric is a single value of a metric
+type Metric struct {
+	Name  string
+	Unit  string
+	Value float64
+}
+
+// Publisher publishes metrics to CloudWatch in the embedded metric format (EMF).
+// Lambda forwards the lines written to stdout to CloudWatch Logs, which extracts the metrics from them.
+type Publisher struct {
+	namespace string
+	now       func() time.Time
+
+	mu     sync.Mutex
+	output io.Writer
+}
+
+// NewPublisher creates a new Publisher writing to output, stdout when nil
+func NewPublisher(namespace string, output io.Writer) *Publisher {
+	if output == nil {
+		output = os.Stdout
+	}
+
+	return &Publisher{
+		namespace: namespace,
+		now:       time.Now,
+		output:    output,
+	}
+}
+
+// emfMetric is the definition of a metric in the EMF metadata
+type emfMetric struct {
+	Name string `json:"Name"`
+	Unit string `json:"Unit"`
+}
+
+// emfDirective is a set of metrics in the EMF metadata
+type emfDirective struct {
+	Namespace  string      `json:"Namespace"`
+	Dimensions [][]string  `json:"Dimensions"`
+	Metrics    []emfMetric `json:"Metrics"`
+}
+
+// emfMetadata is the _aws member of an EMF document
+type emfMetadata struct {
+	Timestamp         int64          `json:"Timestamp"`
+	CloudWatchMetrics []emfDirective `json:"CloudWatchMetrics"`
+}
+
+// Publish writes the metrics with the given dimensions as one EMF document
+func (p *Publisher) Publish(dimensions map[string]string, metrics ...Metric) error {
+	if len(metrics) == 0 {
+		return nil
+	}
+
+	directive := emfDirective{
+		Namespace:  p.namespace,
+		Dimensions: [][]string{slices.Sorted(maps.Keys(dimensions))},
+	}
+	document := make(map[string]any, len(dimensions)+len(metrics)+1)
+	for name, value := range dimensions {
+		document[name] = value
+	}
+	for _, metric := range metrics {
+		directive.Metrics = append(directive.Metrics, emfMetric{Name: metric.Name, Unit: metric.Unit})
+		document[metric.Name] = metric.Value
+	}
+	document["_aws"] = emfMetadata{
+		Timestamp:         p.now().UnixMilli(),
+		CloudWatchMetrics: []emfDirective{directive},
+	}
+
+	line, err := json.Marshal(document)
+	if err != nil {
+		return fmt.Errorf("error marshalling metrics: %w", err)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if _, err := p.output.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("error writing metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,48 @@
+package metrics
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPublisher_Publish(t *testing.T) {
+	var output bytes.Buffer
+	publisher := NewPublisher(DefaultNamespace, &output)
+	publisher.now = func() time.Time { return time.UnixMilli(1718000000000) }
+
+	err := publisher.Publish(map[string]string{"Source": "github"},
+		Metric{Name: "GitHubRateLimitRemaining", Unit: UnitCount, Value: 4999},
+		Metric{Name: "GitHubRateLimitResetSeconds", Unit: UnitSeconds, Value: 1800},
+	)
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"_aws": {
+			"Timestamp": 1718000000000,
+			"CloudWatchMetrics": [{
+				"Namespace": "BlogAPI",
+				"Dimensions": [["Source"]],
+				"Metrics": [
+					{"Name": "GitHubRateLimitRemaining", "Unit": "Count"},
+					{"Name": "GitHubRateLimitResetSeconds", "Unit": "Seconds"}
+				]
+			}]
+		},
+		"Source": "github",
+		"GitHubRateLimitRemaining": 4999,
+		"GitHubRateLimitResetSeconds": 1800
+	}`, output.String())
+	assert.Equal(t, byte('\n'), output.Bytes()[output.Len()-1])
+}
+
+func TestPublisher_PublishNothing(t *testing.T) {
+	var output bytes.Buffer
+
+	err := NewPublisher(DefaultNamespace, &output).Publish(nil)
+
+	assert.NoError(t, err)
+	assert.Empty(t, output.String())
+}
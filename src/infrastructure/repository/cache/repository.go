@@ -0,0 +1,222 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+)
+
+// DefaultTTL is how long loaded posts are served from the cache
+const DefaultTTL = 5 * time.Minute
+
+// CachedRepository implements the PostRepository interface by caching the posts of another repository
+type CachedRepository struct {
+	repository blog.PostRepository
+	ttl        time.Duration
+	now        func() time.Time
+
+	// loadMu serializes loads, so concurrent requests on a cold cache share a single load
+	loadMu sync.Mutex
+
+	mu        sync.RWMutex
+	posts     []blog.Post
+	report    blog.LoadReport
+	loadedAt  time.Time
+	lastError error
+
+	// invalidated marks the cached posts stale before the ttl expires
+	invalidated bool
+}
+
+// NewCachedRepository creates a new CachedRepository serving the posts of the repository for the ttl
+func NewCachedRepository(repository blog.PostRepository, ttl time.Duration) *CachedRepository {
+	if ttl <= 0 {
+		ttl = DefaultTTL
+	}
+
+	return &CachedRepository{
+		repository: repository,
+		ttl:        ttl,
+		now:        time.Now,
+	}
+}
+
+// FetchPosts returns the cached posts, loading them from the repository when the cache is empty or stale.
+// Stale posts are served while the repository is rate limited.
+func (r *CachedRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	if posts, report, ok := r.cached(); ok {
+		return posts, report, nil
+	}
+
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+
+	// Another request may have loaded the posts while this one was waiting
+	if posts, report, ok := r.cached(); ok {
+		return posts, report, nil
+	}
+
+	posts, report, err := r.repository.FetchPosts(ctx)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.lastError = err
+	if errors.Is(err, blog.ErrRateLimited) && !r.loadedAt.IsZero() {
+		// Serve the stale posts until the rate limit resets
+		return slices.Clone(r.posts), r.report, nil
+	}
+	if err != nil {
+		return nil, report, err
+	}
+	r.posts, r.report, r.loadedAt = posts, report, r.now()
+	r.invalidated = false
+
+	return slices.Clone(posts), report, nil
+}
+
+// cached returns a copy of the cached posts when they are fresh
+func (r *CachedRepository) cached() ([]blog.Post, blog.LoadReport, bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	if !r.fresh() {
+		return nil, blog.LoadReport{}, false
+	}
+	return slices.Clone(r.posts), r.report, true
+}
+
+// fresh reports whether the cached posts may be served without loading them again
+func (r *CachedRepository) fresh() bool {
+	return !r.loadedAt.IsZero() && !r.invalidated && r.now().Sub(r.loadedAt) < r.ttl
+}
+
+// Invalidate marks the cached posts stale, so the next request loads them again.
+// The stale posts are still served while the repository is rate limited.
+func (r *CachedRepository) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.invalidated = true
+}
+
+// RefreshPosts refreshes the cached posts of the changed files without loading all posts again.
+// Posts whose file no longer exists are dropped. The cache is invalidated instead when the repository cannot fetch
+// single posts, or when a post fails to load, so its error is reported by the next load.
+func (r *CachedRepository) RefreshPosts(ctx context.Context, filenames []string) error {
+	fetcher, ok := r.repository.(blog.PostFetcher)
+	if !ok {
+		r.Invalidate()
+		return nil
+	}
+
+	r.loadMu.Lock()
+	defer r.loadMu.Unlock()
+
+	r.mu.RLock()
+	loaded := !r.loadedAt.IsZero()
+	r.mu.RUnlock()
+	if !loaded {
+		// Nothing cached yet, the next request loads the posts
+		return nil
+	}
+
+	refreshed := make(map[string]blog.Post, len(filenames))
+	for _, filename := range filenames {
+		post, err := fetcher.FetchPost(ctx, filename)
+		if errors.Is(err, blog.ErrFileNotFound) {
+			continue
+		}
+		if err != nil {
+			r.Invalidate()
+			return fmt.Errorf("failed to refresh %s: %w", filename, err)
+		}
+		refreshed[filename] = post
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.posts, r.report = refresh(r.posts, r.report, filenames, refreshed)
+	return nil
+}
+
+// refresh replaces the posts of the changed files and their entries in the report
+func refresh(posts []blog.Post, report blog.LoadReport, filenames []string, refreshed map[string]blog.Post) ([]blog.Post, blog.LoadReport) {
+	changed := func(filename string) bool {
+		return slices.Contains(filenames, filename)
+	}
+
+	posts = slices.DeleteFunc(slices.Clone(posts), func(post blog.Post) bool { return changed(post.Filename) })
+	report.Errors = slices.DeleteFunc(slices.Clone(report.Errors), func(e blog.LoadError) bool { return changed(e.Filename) })
+	report.Warnings = slices.DeleteFunc(slices.Clone(report.Warnings), func(w blog.LoadWarning) bool { return changed(w.Filename) })
+
+	for _, filename := range filenames {
+		if post, ok := refreshed[filename]; ok {
+			posts = append(posts, post)
+			report.AddWarnings(filename, post.Warnings)
+		}
+	}
+	report.Posts = len(posts)
+	return posts, report
+}
+
+// CheckHealth checks the underlying repository and reports the freshness of the cache and the last load errors
+func (r *CachedRepository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	var checks []blog.HealthCheck
+	if checker, ok := r.repository.(blog.HealthChecker); ok {
+		checks = checker.CheckHealth(ctx)
+	}
+
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	return append(checks, r.cacheCheck(), r.loadCheck())
+}
+
+// cacheCheck reports the age of the cached posts, failing when the last refresh failed
+func (r *CachedRepository) cacheCheck() blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name:    "cache",
+		Healthy: r.lastError == nil,
+		Details: map[string]any{
+			"ttl_seconds": int(r.ttl.Seconds()),
+		},
+	}
+
+	if !r.loadedAt.IsZero() {
+		age := r.now().Sub(r.loadedAt)
+		check.Details["loaded_at"] = r.loadedAt
+		check.Details["age_seconds"] = int(age.Seconds())
+		check.Details["fresh"] = r.fresh()
+	}
+
+	switch {
+	case r.lastError != nil:
+		check.Message = fmt.Sprintf("last refresh failed: %v", r.lastError)
+	case r.loadedAt.IsZero():
+		check.Message = "posts not loaded yet"
+	}
+	return check
+}
+
+// loadCheck counts the posts that failed to load on the last load. The failures themselves name files
+// and upstream errors, they are listed by the load report of the admin endpoint only.
+func (r *CachedRepository) loadCheck() blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name:    "posts",
+		Healthy: !r.report.HasErrors(),
+		Details: map[string]any{
+			"posts":    r.report.Posts,
+			"errors":   len(r.report.Errors),
+			"warnings": len(r.report.Warnings),
+		},
+	}
+
+	if r.report.HasErrors() {
+		check.Message = fmt.Sprintf("%d posts failed to load", len(r.report.Errors))
+	}
+	return check
+}
@@ -0,0 +1,211 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubPostRepository is a stub implementation of the PostRepository and HealthChecker interfaces
+type StubPostRepository struct {
+	posts  []blog.Post
+	report blog.LoadReport
+	err    error
+	calls  int
+}
+
+func (s *StubPostRepository) FetchPosts(_ context.Context) ([]blog.Post, blog.LoadReport, error) {
+	s.calls++
+	return s.posts, s.report, s.err
+}
+
+func (s *StubPostRepository) CheckHealth(_ context.Context) []blog.HealthCheck {
+	return []blog.HealthCheck{{Name: "stub", Healthy: true}}
+}
+
+// clock is a manually advanced time source
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	return c.now
+}
+
+func newTestRepository(repository blog.PostRepository) (*CachedRepository, *clock) {
+	c := &clock{now: time.Date(2024, 5, 16, 12, 0, 0, 0, time.UTC)}
+	cached := NewCachedRepository(repository, time.Minute)
+	cached.now = c.Now
+	return cached, c
+}
+
+func TestCachedRepository_FetchPosts(t *testing.T) {
+	stub := &StubPostRepository{posts: []blog.Post{{Filename: "test1.md"}}}
+	repository, clock := newTestRepository(stub)
+
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	// Served from the cache while fresh
+	clock.now = clock.now.Add(30 * time.Second)
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stub.calls)
+
+	// Reloaded once stale
+	clock.now = clock.now.Add(time.Minute)
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stub.calls)
+}
+
+func TestCachedRepository_FetchPosts_ReturnsCopies(t *testing.T) {
+	stub := &StubPostRepository{posts: []blog.Post{{Filename: "test1.md"}, {Filename: "test2.md"}}}
+	repository, _ := newTestRepository(stub)
+
+	posts, _, _ := repository.FetchPosts(context.Background())
+	posts[0], posts[1] = posts[1], posts[0]
+
+	cached, _, _ := repository.FetchPosts(context.Background())
+	assert.Equal(t, "test1.md", cached[0].Filename)
+}
+
+func TestCachedRepository_CheckHealth(t *testing.T) {
+	report := blog.NewLoadReport(time.Now())
+	report.AddError("broken.md", blog.LoadErrorParse, errors.New("invalid frontmatter"))
+	stub := &StubPostRepository{posts: []blog.Post{{Filename: "test1.md"}}, report: report}
+	repository, clock := newTestRepository(stub)
+
+	checks := repository.CheckHealth(context.Background())
+	assert.Len(t, checks, 3)
+	assert.True(t, checks[0].Healthy)
+	assert.True(t, checks[1].Healthy)
+	assert.Equal(t, "posts not loaded yet", checks[1].Message)
+
+	_, _, _ = repository.FetchPosts(context.Background())
+	clock.now = clock.now.Add(90 * time.Second)
+
+	checks = repository.CheckHealth(context.Background())
+	assert.True(t, checks[1].Healthy)
+	assert.Equal(t, 90, checks[1].Details["age_seconds"])
+	assert.Equal(t, false, checks[1].Details["fresh"])
+	assert.False(t, checks[2].Healthy)
+	assert.Equal(t, "1 posts failed to load", checks[2].Message)
+	assert.Equal(t, 1, checks[2].Details["errors"])
+	// The failures name files and upstream errors, they are left to the admin load report
+	assert.NotContains(t, checks[2].Details, "failed")
+
+	stub.err = errors.New("GitHub API failure")
+	_, _, err := repository.FetchPosts(context.Background())
+	assert.Error(t, err)
+
+	checks = repository.CheckHealth(context.Background())
+	assert.False(t, checks[1].Healthy)
+	assert.Equal(t, "last refresh failed: GitHub API failure", checks[1].Message)
+}
+
+func TestCachedRepository_FetchPosts_ServesStalePostsWhenRateLimited(t *testing.T) {
+	stub := &StubPostRepository{posts: []blog.Post{{Filename: "test1.md"}}}
+	repository, clock := newTestRepository(stub)
+
+	// Nothing cached yet, the error is returned
+	stub.err = blog.ErrRateLimited
+	_, _, err := repository.FetchPosts(context.Background())
+	assert.ErrorIs(t, err, blog.ErrRateLimited)
+
+	stub.err = nil
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+
+	clock.now = clock.now.Add(2 * time.Minute)
+	stub.err = blog.ErrRateLimited
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+
+	stub.err = errors.New("GitHub API failure")
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.Error(t, err)
+}
+
+// StubPostFetcher is a stub repository also fetching single posts
+type StubPostFetcher struct {
+	StubPostRepository
+	files map[string]blog.Post
+	err   error
+}
+
+func (s *StubPostFetcher) FetchPost(_ context.Context, filename string) (blog.Post, error) {
+	if s.err != nil {
+		return blog.Post{}, s.err
+	}
+	post, ok := s.files[filename]
+	if !ok {
+		return blog.Post{}, blog.ErrFileNotFound
+	}
+	return post, nil
+}
+
+func TestCachedRepository_RefreshPosts(t *testing.T) {
+	stub := &StubPostFetcher{StubPostRepository: StubPostRepository{
+		posts: []blog.Post{{Filename: "a.md", Title: "A"}, {Filename: "b.md", Title: "B"}},
+		report: blog.LoadReport{
+			Posts:    2,
+			Errors:   []blog.LoadError{{Filename: "c.md", Kind: blog.LoadErrorParse, Message: "bad"}},
+			Warnings: []blog.LoadWarning{{Filename: "a.md", Message: "old warning"}},
+		},
+	}}
+	repository, _ := newTestRepository(stub)
+	_, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+
+	// a.md was edited, b.md deleted and c.md fixed
+	stub.files = map[string]blog.Post{
+		"a.md": {Filename: "a.md", Title: "A2", Warnings: []string{"new warning"}},
+		"c.md": {Filename: "c.md", Title: "C"},
+	}
+	assert.NoError(t, repository.RefreshPosts(context.Background(), []string{"a.md", "b.md", "c.md"}))
+
+	posts, report, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 1, stub.calls)
+	assert.Equal(t, []blog.Post{
+		{Filename: "a.md", Title: "A2", Warnings: []string{"new warning"}},
+		{Filename: "c.md", Title: "C"},
+	}, posts)
+	assert.Equal(t, 2, report.Posts)
+	assert.Empty(t, report.Errors)
+	assert.Equal(t, []blog.LoadWarning{{Filename: "a.md", Message: "new warning"}}, report.Warnings)
+}
+
+func TestCachedRepository_RefreshPosts_Invalidates(t *testing.T) {
+	// A failing post invalidates the cache, it is reported by the next load
+	stub := &StubPostFetcher{StubPostRepository: StubPostRepository{posts: []blog.Post{{Filename: "a.md"}}}}
+	repository, _ := newTestRepository(stub)
+	_, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+
+	stub.err = errors.New("markdown parsing failure")
+	assert.Error(t, repository.RefreshPosts(context.Background(), []string{"a.md"}))
+	assert.False(t, repository.CheckHealth(context.Background())[1].Details["fresh"].(bool))
+
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, stub.calls)
+
+	// Repositories without single post fetches are invalidated
+	plain := &StubPostRepository{posts: []blog.Post{{Filename: "a.md"}}}
+	cached, _ := newTestRepository(plain)
+	_, _, err = cached.FetchPosts(context.Background())
+	assert.NoError(t, err)
+
+	assert.NoError(t, cached.RefreshPosts(context.Background(), []string{"a.md"}))
+	_, _, err = cached.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, 2, plain.calls)
+}
@@ -0,0 +1,40 @@
+package git
+
+import (
+	"os"
+	"path/filepath"
+)
+
+// DefaultDir is the directory the remote is cloned into when no directory is configured.
+// /tmp is the only writable directory of a Lambda function, and is kept between warm invocations.
+var DefaultDir = filepath.Join(os.TempDir(), "blog-posts.git")
+
+// Config holds the configuration for the git repository
+type Config struct {
+	// URL is the URL of the remote, e.g. https://github.com/buyallmemes/blog-api.git or file:///srv/blog.git.
+	// Empty opens the existing clone in Dir without fetching.
+	URL string
+
+	// Dir is the directory of the clone. A remote is cloned into it as a bare mirror unless it already holds a clone.
+	Dir string
+
+	// Path is the path to the blog posts directory in the repository
+	Path string
+
+	// Ref is the branch, tag or commit the posts are read at. Empty uses HEAD.
+	Ref string
+
+	// Token is the access token of a remote served over HTTPS
+	Token string
+}
+
+// NewConfig creates a new Config instance with the given parameters
+func NewConfig(url, dir, path, ref, token string) *Config {
+	return &Config{
+		URL:   url,
+		Dir:   dir,
+		Path:  path,
+		Ref:   ref,
+		Token: token,
+	}
+}
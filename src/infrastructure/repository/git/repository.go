@@ -0,0 +1,377 @@
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/githistory"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/pool"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/transport"
+	"github.com/go-git/go-git/v5/plumbing/transport/client"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/go-git/go-git/v5/plumbing/transport/server"
+)
+
+// Common errors
+var (
+	ErrInvalidConfig    = errors.New("invalid repository configuration")
+	ErrGitFailure       = errors.New("git failure")
+	ErrContentDecoding  = errors.New("content decoding failure")
+	ErrMarkdownParsing  = errors.New("markdown parsing failure")
+	ErrContextCancelled = pool.ErrContextCancelled
+)
+
+// installFileTransport serves file:// remotes in-process, the file transport of go-git runs a git binary
+// the Lambda runtime does not have
+var installFileTransport = sync.OnceFunc(func() {
+	client.InstallProtocol("file", server.NewClient(server.DefaultLoader))
+})
+
+// GitRepository implements the PostRepository interface using a git repository cloned to the local filesystem.
+// Posts are read from the objects of the clone, and dated and attributed by the history of their files.
+type GitRepository struct {
+	markdownParser blog.MarkdownParser
+	config         *Config
+
+	// mu guards the clone, go-git repositories are not safe for concurrent use.
+	// The clone is opened on first use, nil until then.
+	mu         sync.Mutex
+	repository *gogit.Repository
+
+	// histories caches the history of the posts at historyHash, the commit it was computed at
+	histories   map[string][]blog.Revision
+	historyHash plumbing.Hash
+}
+
+// postFile is the content of a post read from the tree of a commit
+type postFile struct {
+	filename string
+	content  []byte
+}
+
+// NewGitRepository creates a new GitRepository instance for the clone in the directory of the config.
+// The clone is opened on first use, cloning the remote as a bare mirror when the directory does not hold a clone yet.
+func NewGitRepository(markdownParser blog.MarkdownParser, config *Config) (*GitRepository, error) {
+	if markdownParser == nil {
+		return nil, fmt.Errorf("%w: markdown parser is required", ErrInvalidConfig)
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("%w: config is required", ErrInvalidConfig)
+	}
+
+	normalized := *config
+	if normalized.Dir == "" {
+		if normalized.URL == "" {
+			return nil, fmt.Errorf("%w: URL or directory is required", ErrInvalidConfig)
+		}
+		normalized.Dir = DefaultDir
+	}
+	normalized.Path = strings.Trim(normalized.Path, "/")
+
+	if strings.HasPrefix(normalized.URL, "file://") {
+		installFileTransport()
+	}
+
+	return &GitRepository{
+		markdownParser: markdownParser,
+		config:         &normalized,
+	}, nil
+}
+
+// open opens the clone unless it is already open
+func (r *GitRepository) open(ctx context.Context) error {
+	if r.repository != nil {
+		return nil
+	}
+
+	repository, err := openRepository(ctx, r.config)
+	if err != nil {
+		return err
+	}
+	r.repository = repository
+	return nil
+}
+
+// openRepository opens the clone in the directory, cloning the remote into it when it does not exist
+func openRepository(ctx context.Context, config *Config) (*gogit.Repository, error) {
+	repository, err := gogit.PlainOpen(config.Dir)
+	if err == nil {
+		return repository, nil
+	}
+	if !errors.Is(err, gogit.ErrRepositoryNotExists) || config.URL == "" {
+		return nil, fmt.Errorf("%w: failed to open %s: %v", ErrGitFailure, config.Dir, err)
+	}
+
+	repository, err = gogit.PlainCloneContext(ctx, config.Dir, true, &gogit.CloneOptions{
+		URL:    config.URL,
+		Auth:   auth(config),
+		Mirror: true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to clone %s: %v", ErrGitFailure, config.URL, err)
+	}
+	return repository, nil
+}
+
+// auth returns the credentials of the remote, nil without a token
+func auth(config *Config) transport.AuthMethod {
+	if config.Token == "" {
+		return nil
+	}
+	// Git hosts ignore the username of token authentication, but it must not be empty
+	return &githttp.BasicAuth{Username: "git", Password: config.Token}
+}
+
+// FetchPosts fetches the remote and parses all blog posts at the configured ref.
+// Posts that fail to load are skipped and listed in the report.
+func (r *GitRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
+	// Add timeout to context if not already set
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	files, histories, err := r.readPosts(ctx)
+	if err != nil {
+		return nil, report, err
+	}
+
+	if len(files) == 0 {
+		return []blog.Post{}, report, nil
+	}
+
+	posts, err := pool.Loader[postFile]{
+		Name: func(file postFile) string { return file.filename },
+		Load: func(ctx context.Context, file postFile) (blog.Post, error) {
+			return r.parsePost(ctx, file, histories[file.filename])
+		},
+		Kind: pool.ErrorKind(ErrContentDecoding, ErrMarkdownParsing),
+	}.LoadPosts(ctx, files, &report)
+	if err != nil {
+		return nil, report, err
+	}
+
+	return posts, report, nil
+}
+
+// readPosts opens the clone and fetches the remote, then reads the markdown files of the posts directory at the ref and their history
+func (r *GitRepository) readPosts(ctx context.Context) ([]postFile, map[string][]blog.Revision, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.open(ctx); err != nil {
+		return nil, nil, err
+	}
+	if err := r.fetch(ctx); err != nil {
+		return nil, nil, err
+	}
+
+	commit, err := r.resolve()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %v", ErrGitFailure, err)
+	}
+	if r.config.Path != "" {
+		tree, err = tree.Tree(r.config.Path)
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: failed to read %s at %s: %v", ErrGitFailure, r.config.Path, commit.Hash, err)
+		}
+	}
+
+	var files []postFile
+	var paths []string
+	for _, entry := range tree.Entries {
+		if !entry.Mode.IsFile() || !strings.HasSuffix(entry.Name, ".md") {
+			continue
+		}
+		content, err := r.readBlob(entry.Hash)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to read %s: %w", entry.Name, err)
+		}
+		files = append(files, postFile{filename: entry.Name, content: content})
+		paths = append(paths, path.Join(r.config.Path, entry.Name))
+	}
+
+	histories, err := r.history(commit, paths)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Key the histories by filename, like the posts
+	byFilename := make(map[string][]blog.Revision, len(histories))
+	for filePath, revisions := range histories {
+		byFilename[path.Base(filePath)] = revisions
+	}
+	return files, byFilename, nil
+}
+
+// fetch updates the clone from the remote, clones opened without a URL are not fetched
+func (r *GitRepository) fetch(ctx context.Context) error {
+	if r.config.URL == "" {
+		return nil
+	}
+
+	err := r.repository.FetchContext(ctx, &gogit.FetchOptions{
+		RemoteURL: r.config.URL,
+		Auth:      auth(r.config),
+		Force:     true,
+	})
+	if err != nil && !errors.Is(err, gogit.NoErrAlreadyUpToDate) {
+		if ctx.Err() != nil {
+			return fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
+		}
+		return fmt.Errorf("%w: failed to fetch %s: %v", ErrGitFailure, r.config.URL, err)
+	}
+	return nil
+}
+
+// resolve returns the commit of the configured ref
+func (r *GitRepository) resolve() (*object.Commit, error) {
+	ref := r.config.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+
+	hash, err := r.repository.ResolveRevision(plumbing.Revision(ref))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to resolve %s: %v", ErrGitFailure, ref, err)
+	}
+
+	commit, err := r.repository.CommitObject(*hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to read commit %s: %v", ErrGitFailure, hash, err)
+	}
+	return commit, nil
+}
+
+// readBlob reads the content of a blob
+func (r *GitRepository) readBlob(hash plumbing.Hash) ([]byte, error) {
+	blob, err := r.repository.BlobObject(hash)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGitFailure, err)
+	}
+
+	reader, err := blob.Reader()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGitFailure, err)
+	}
+	defer reader.Close()
+
+	content, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGitFailure, err)
+	}
+	return content, nil
+}
+
+// history walks the history of the commit, newest first, for the revisions of each path.
+// The history is cached until the ref points to another commit.
+func (r *GitRepository) history(commit *object.Commit, paths []string) (map[string][]blog.Revision, error) {
+	if r.historyHash == commit.Hash {
+		return r.histories, nil
+	}
+
+	histories, err := githistory.Walk(r.repository, commit.Hash, paths)
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to walk the history of %s: %v", ErrGitFailure, commit.Hash, err)
+	}
+
+	r.histories, r.historyHash = histories, commit.Hash
+	return histories, nil
+}
+
+// parsePost parses a post, dating and attributing it by the revisions of its file
+func (r *GitRepository) parsePost(ctx context.Context, file postFile, revisions []blog.Revision) (blog.Post, error) {
+	if !utf8.Valid(file.content) {
+		return blog.Post{}, fmt.Errorf("%w: %s is not valid UTF-8", ErrContentDecoding, file.filename)
+	}
+
+	parsed, err := r.markdownParser.ParseDocument(ctx, blog.Document{
+		Filename: file.filename,
+		Content:  string(file.content),
+		Files:    r,
+	})
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("%w: %v", ErrMarkdownParsing, err)
+	}
+
+	post := blog.NewPost(file.filename, string(file.content), parsed)
+	post.SetRevisions(revisions)
+	return post, nil
+}
+
+// ReadFile reads a file by its path relative to the root of the repository, at the configured ref
+func (r *GitRepository) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	cleaned, err := blog.CleanPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.open(ctx); err != nil {
+		return nil, err
+	}
+
+	commit, err := r.resolve()
+	if err != nil {
+		return nil, err
+	}
+
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrGitFailure, err)
+	}
+
+	entry, err := tree.FindEntry(cleaned)
+	if err != nil || !entry.Mode.IsFile() {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, cleaned)
+	}
+	return r.readBlob(entry.Hash)
+}
+
+// CheckHealth checks that the ref resolves to a commit of the clone
+func (r *GitRepository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name: "git",
+		Details: map[string]any{
+			"dir":  r.config.Dir,
+			"path": r.config.Path,
+			"ref":  r.config.Ref,
+		},
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if err := r.open(ctx); err != nil {
+		check.Message = err.Error()
+		return []blog.HealthCheck{check}
+	}
+	commit, err := r.resolve()
+	if err != nil {
+		check.Message = err.Error()
+		return []blog.HealthCheck{check}
+	}
+
+	check.Healthy = true
+	check.Details["commit"] = commit.Hash.String()
+	return []blog.HealthCheck{check}
+}
@@ -0,0 +1,293 @@
+package git
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+// sourceRepository is a local git repository the tests clone through a file:// URL
+type sourceRepository struct {
+	t          *testing.T
+	dir        string
+	repository *gogit.Repository
+}
+
+func newSourceRepository(t *testing.T) *sourceRepository {
+	dir := t.TempDir()
+	repository, err := gogit.PlainInit(dir, false)
+	assert.NoError(t, err)
+	return &sourceRepository{t: t, dir: dir, repository: repository}
+}
+
+// url returns the file:// URL of the repository
+func (s *sourceRepository) url() string {
+	return "file://" + filepath.Join(s.dir, ".git")
+}
+
+// commit writes the files, removing those with empty content, and commits them as the author at the time
+func (s *sourceRepository) commit(author string, when time.Time, files map[string]string) plumbing.Hash {
+	return s.merge(author, when, nil, files)
+}
+
+// merge commits the files like commit, with the given parents instead of HEAD
+func (s *sourceRepository) merge(author string, when time.Time, parents []plumbing.Hash, files map[string]string) plumbing.Hash {
+	worktree, err := s.repository.Worktree()
+	assert.NoError(s.t, err)
+
+	for name, content := range files {
+		filePath := filepath.Join(s.dir, filepath.FromSlash(name))
+		if content == "" {
+			_, err = worktree.Remove(name)
+			assert.NoError(s.t, err)
+			continue
+		}
+		assert.NoError(s.t, os.MkdirAll(filepath.Dir(filePath), 0o755))
+		assert.NoError(s.t, os.WriteFile(filePath, []byte(content), 0o644))
+		_, err = worktree.Add(name)
+		assert.NoError(s.t, err)
+	}
+
+	signature := &object.Signature{Name: author, Email: author + "@example.com", When: when}
+	hash, err := worktree.Commit("Update posts", &gogit.CommitOptions{Author: signature, Committer: signature, Parents: parents})
+	assert.NoError(s.t, err)
+	return hash
+}
+
+var (
+	created = time.Date(2024, 5, 16, 10, 0, 0, 0, time.UTC)
+	edited  = time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func newSourceWithPosts(t *testing.T) *sourceRepository {
+	source := newSourceRepository(t)
+	source.commit("alice", created, map[string]string{
+		"posts/20240516-good.md":    "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n",
+		"posts/20240517-broken.md":  "---\ntitle: Broken\ndate: 17.05.2024\n---\n{{< vimeo 1 >}}\n",
+		"posts/20240518-warning.md": "---\ntitle: Warning\n---\nHello!\n",
+		"posts/notes.txt":           "not a post",
+		"posts/drafts/draft.md":     "---\ntitle: Draft\ndate: 19.05.2024\n---\nHello!\n",
+		"examples/main.go":          "package main\n",
+	})
+	source.commit("bob", edited, map[string]string{
+		"posts/20240516-good.md": "---\ntitle: Good\ndate: 16.05.2024\n---\nHello again!\n",
+	})
+	return source
+}
+
+func newTestRepository(t *testing.T, source *sourceRepository, ref string) *GitRepository {
+	repository, err := NewGitRepository(markdown.NewGoldmarkParser(),
+		NewConfig(source.url(), filepath.Join(t.TempDir(), "clone.git"), "posts", ref, ""))
+	assert.NoError(t, err)
+	return repository
+}
+
+// postsByFilename indexes the posts by their filename
+func postsByFilename(posts []blog.Post) map[string]blog.Post {
+	byFilename := make(map[string]blog.Post, len(posts))
+	for _, post := range posts {
+		byFilename[post.Filename] = post
+	}
+	return byFilename
+}
+
+func TestGitRepository_FetchPosts(t *testing.T) {
+	repository := newTestRepository(t, newSourceWithPosts(t), "")
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, 2, report.Posts)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, "20240517-broken.md", report.Errors[0].Filename)
+	assert.Equal(t, blog.LoadErrorParse, report.Errors[0].Kind)
+	assert.Equal(t, []blog.LoadWarning{{
+		Filename: "20240518-warning.md",
+		Message:  `frontmatter field "date" is required`,
+	}}, report.Warnings)
+
+	good := postsByFilename(posts)["20240516-good.md"]
+	assert.Contains(t, good.Content, "Hello again!")
+	assert.Equal(t, edited, good.UpdatedAt)
+	assert.Equal(t, "alice", good.Author)
+	assert.Equal(t, []string{"alice", "bob"}, good.Authors)
+	assert.Len(t, good.Revisions, 2)
+	assert.Equal(t, blog.Revision{
+		SHA:     good.Revisions[0].SHA,
+		Date:    edited,
+		Author:  "bob",
+		Message: "Update posts",
+	}, good.Revisions[0])
+
+	warning := postsByFilename(posts)["20240518-warning.md"]
+	assert.Equal(t, created, warning.UpdatedAt)
+	assert.Equal(t, "alice", warning.Author)
+}
+
+func TestGitRepository_FetchPosts_Merge(t *testing.T) {
+	source := newSourceWithPosts(t)
+	head, err := source.repository.Head()
+	assert.NoError(t, err)
+	base := head.Hash()
+
+	// carol edits the good post on a branch, while dave edits the warning post on the main line
+	branch := source.commit("carol", edited.Add(time.Hour), map[string]string{
+		"posts/20240516-good.md": "---\ntitle: Good\ndate: 16.05.2024\n---\nHello from the branch!\n",
+	})
+	worktree, err := source.repository.Worktree()
+	assert.NoError(t, err)
+	assert.NoError(t, worktree.Reset(&gogit.ResetOptions{Commit: base, Mode: gogit.HardReset}))
+	main := source.commit("dave", edited.Add(2*time.Hour), map[string]string{
+		"posts/20240518-warning.md": "---\ntitle: Warning\n---\nEdited!\n",
+	})
+
+	// erin merges the branch, which changes the good post compared to the main line only
+	source.merge("erin", edited.Add(3*time.Hour), []plumbing.Hash{main, branch}, map[string]string{
+		"posts/20240516-good.md": "---\ntitle: Good\ndate: 16.05.2024\n---\nHello from the branch!\n",
+	})
+
+	posts, _, err := newTestRepository(t, source, "").FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	good := postsByFilename(posts)["20240516-good.md"]
+	assert.Equal(t, []string{"alice", "bob", "carol"}, good.Authors)
+	assert.Equal(t, edited.Add(time.Hour), good.UpdatedAt)
+	warning := postsByFilename(posts)["20240518-warning.md"]
+	assert.Equal(t, []string{"alice", "dave"}, warning.Authors)
+}
+
+func TestGitRepository_FetchPosts_Ref(t *testing.T) {
+	source := newSourceWithPosts(t)
+	head, err := source.repository.Head()
+	assert.NoError(t, err)
+	commit, err := source.repository.CommitObject(head.Hash())
+	assert.NoError(t, err)
+	_, err = source.repository.CreateTag("v1", commit.ParentHashes[0], nil)
+	assert.NoError(t, err)
+
+	posts, _, err := newTestRepository(t, source, "v1").FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	good := postsByFilename(posts)["20240516-good.md"]
+	assert.Contains(t, good.Content, "Hello!")
+	assert.Equal(t, created, good.UpdatedAt)
+}
+
+func TestGitRepository_FetchPosts_FetchesChanges(t *testing.T) {
+	source := newSourceWithPosts(t)
+	repository := newTestRepository(t, source, "")
+
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+
+	later := edited.Add(24 * time.Hour)
+	source.commit("carol", later, map[string]string{
+		"posts/20240601-new.md":     "---\ntitle: New\ndate: 01.06.2024\n---\nHello!\n",
+		"posts/20240518-warning.md": "",
+	})
+
+	posts, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	var filenames []string
+	for _, post := range posts {
+		filenames = append(filenames, post.Filename)
+	}
+	sort.Strings(filenames)
+	assert.Equal(t, []string{"20240516-good.md", "20240601-new.md"}, filenames)
+	assert.Equal(t, "carol", postsByFilename(posts)["20240601-new.md"].Author)
+	assert.Equal(t, later, postsByFilename(posts)["20240601-new.md"].UpdatedAt)
+}
+
+func TestGitRepository_FetchPosts_ReusesClone(t *testing.T) {
+	source := newSourceWithPosts(t)
+	dir := filepath.Join(t.TempDir(), "clone.git")
+	config := NewConfig(source.url(), dir, "posts", "", "")
+
+	repository, err := NewGitRepository(markdown.NewGoldmarkParser(), config)
+	assert.NoError(t, err)
+
+	// The remote is cloned on first use, not when the repository is created
+	assert.NoDirExists(t, dir)
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.DirExists(t, dir)
+
+	// A warm start opens the clone in the directory, which also works without the remote
+	config.URL = ""
+	repository, err = NewGitRepository(markdown.NewGoldmarkParser(), config)
+	assert.NoError(t, err)
+
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+}
+
+func TestGitRepository_ReadFile(t *testing.T) {
+	repository := newTestRepository(t, newSourceWithPosts(t), "")
+
+	content, err := repository.ReadFile(context.Background(), "examples/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+
+	_, err = repository.ReadFile(context.Background(), "examples/missing.go")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "posts/drafts")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "../outside.go")
+	assert.ErrorIs(t, err, blog.ErrInvalidPath)
+}
+
+func TestGitRepository_CheckHealth(t *testing.T) {
+	source := newSourceWithPosts(t)
+
+	checks := newTestRepository(t, source, "").CheckHealth(context.Background())
+	assert.Len(t, checks, 1)
+	assert.True(t, checks[0].Healthy)
+
+	checks = newTestRepository(t, source, "missing").CheckHealth(context.Background())
+	assert.False(t, checks[0].Healthy)
+}
+
+func TestNewGitRepository_InvalidConfig(t *testing.T) {
+	parser := markdown.NewGoldmarkParser()
+
+	_, err := NewGitRepository(nil, NewConfig("file:///srv/blog.git", "", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGitRepository(parser, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGitRepository(parser, NewConfig("", "", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
+
+func TestGitRepository_FetchPosts_OpenFailure(t *testing.T) {
+	parser := markdown.NewGoldmarkParser()
+
+	// The directory holds no clone and there is no remote to clone it from
+	repository, err := NewGitRepository(parser, NewConfig("", t.TempDir(), "posts", "", ""))
+	assert.NoError(t, err)
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.ErrorIs(t, err, ErrGitFailure)
+
+	// The remote is not a git repository
+	repository, err = NewGitRepository(parser, NewConfig("file://"+t.TempDir(), filepath.Join(t.TempDir(), "clone.git"), "posts", "", ""))
+	assert.NoError(t, err)
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.ErrorIs(t, err, ErrGitFailure)
+	assert.False(t, repository.CheckHealth(context.Background())[0].Healthy)
+}
@@ -0,0 +1,39 @@
+package gitea
+
+import "net/http"
+
+// Config holds the configuration for the Gitea repository
+type Config struct {
+	// BaseURL is the URL of the Gitea server, without the /api/v1 suffix
+	BaseURL string
+
+	// Owner is the Gitea repository owner, a user or an organization
+	Owner string
+
+	// Repo is the Gitea repository name
+	Repo string
+
+	// Path is the path to the blog posts directory in the repository
+	Path string
+
+	// Ref is the branch, tag or commit the posts are read at. Empty uses the default branch.
+	Ref string
+
+	// Token is the Gitea access token
+	Token string
+
+	// HTTPClient is the HTTP client of the API requests, defaulting to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// NewConfig creates a new Config instance with the given parameters
+func NewConfig(baseURL, owner, repo, path, ref, token string) *Config {
+	return &Config{
+		BaseURL: baseURL,
+		Owner:   owner,
+		Repo:    repo,
+		Path:    path,
+		Ref:     ref,
+		Token:   token,
+	}
+}
@@ -0,0 +1,193 @@
+package gitea
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/pool"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/restapi"
+)
+
+// Common errors
+var (
+	ErrInvalidConfig    = errors.New("invalid repository configuration")
+	ErrGiteaAPIFailure  = errors.New("Gitea API failure")
+	ErrContentDecoding  = restapi.ErrContentDecoding
+	ErrMarkdownParsing  = errors.New("markdown parsing failure")
+	ErrContextCancelled = pool.ErrContextCancelled
+)
+
+// GiteaRepository implements the PostRepository interface using the Gitea REST API
+type GiteaRepository struct {
+	api            *restapi.Client
+	markdownParser blog.MarkdownParser
+	config         *Config
+}
+
+// NewGiteaRepository creates a new GiteaRepository instance
+func NewGiteaRepository(markdownParser blog.MarkdownParser, config *Config) (*GiteaRepository, error) {
+	if markdownParser == nil {
+		return nil, fmt.Errorf("%w: markdown parser is required", ErrInvalidConfig)
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("%w: config is required", ErrInvalidConfig)
+	}
+
+	if config.Owner == "" || config.Repo == "" || config.Path == "" {
+		return nil, fmt.Errorf("%w: owner, repo, and path are required", ErrInvalidConfig)
+	}
+
+	if u, err := url.Parse(config.BaseURL); err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%w: invalid base URL %q", ErrInvalidConfig, config.BaseURL)
+	}
+
+	var token string
+	if config.Token != "" {
+		token = "token " + config.Token
+	}
+
+	return &GiteaRepository{
+		api: &restapi.Client{
+			HTTPClient:  config.HTTPClient,
+			BaseURL:     strings.TrimSuffix(config.BaseURL, "/") + "/api/v1/repos/" + url.PathEscape(config.Owner) + "/" + url.PathEscape(config.Repo),
+			Name:        "Gitea API",
+			Failure:     ErrGiteaAPIFailure,
+			TokenHeader: "Authorization",
+			TokenValue:  token,
+			Ref:         config.Ref,
+		},
+		markdownParser: markdownParser,
+		config:         config,
+	}, nil
+}
+
+// contentsEntry is a file or directory of the contents API, with the base64 encoded content of files
+type contentsEntry struct {
+	Name     string `json:"name"`
+	Path     string `json:"path"`
+	Type     string `json:"type"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// FetchPosts fetches all blog posts from Gitea.
+// Posts that fail to load are skipped and listed in the report.
+func (r *GiteaRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
+	// Add timeout to context if not already set
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	entries, err := r.getDirectoryContent(ctx)
+	if err != nil {
+		return nil, report, fmt.Errorf("failed to get directory content: %w", err)
+	}
+
+	// Filter markdown files
+	var mdFiles []contentsEntry
+	for _, entry := range entries {
+		if entry.Type == "file" && strings.HasSuffix(entry.Name, ".md") {
+			mdFiles = append(mdFiles, entry)
+		}
+	}
+
+	if len(mdFiles) == 0 {
+		return []blog.Post{}, report, nil
+	}
+
+	posts, err := pool.Loader[contentsEntry]{
+		Name: func(entry contentsEntry) string { return entry.Name },
+		Load: r.fetchPost,
+		Kind: pool.ErrorKind(ErrContentDecoding, ErrMarkdownParsing),
+	}.LoadPosts(ctx, mdFiles, &report)
+	if err != nil {
+		return nil, report, err
+	}
+
+	return posts, report, nil
+}
+
+// fetchPost fetches a single post from Gitea
+func (r *GiteaRepository) fetchPost(ctx context.Context, entry contentsEntry) (blog.Post, error) {
+	content, err := r.getFileContent(ctx, entry.Path)
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("failed to get post content: %w", err)
+	}
+
+	parsed, err := r.markdownParser.ParseDocument(ctx, blog.Document{
+		Filename: entry.Name,
+		Content:  string(content),
+		Files:    r,
+	})
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("%w: %v", ErrMarkdownParsing, err)
+	}
+
+	return blog.NewPost(entry.Name, string(content), parsed), nil
+}
+
+// getDirectoryContent lists the posts directory
+func (r *GiteaRepository) getDirectoryContent(ctx context.Context) ([]contentsEntry, error) {
+	var entries []contentsEntry
+	if _, err := r.api.Get(ctx, "/contents/"+escapePath(r.config.Path), r.api.RefQuery(), &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// getFileContent gets the decoded content of a file by its path in the repository
+func (r *GiteaRepository) getFileContent(ctx context.Context, filePath string) ([]byte, error) {
+	var raw json.RawMessage
+	if _, err := r.api.Get(ctx, "/contents/"+escapePath(filePath), r.api.RefQuery(), &raw); err != nil {
+		return nil, err
+	}
+
+	// Directories are listed as an array of their entries
+	if strings.HasPrefix(string(raw), "[") {
+		return nil, fmt.Errorf("%w: %w: %s is a directory", ErrGiteaAPIFailure, restapi.ErrNotFound, filePath)
+	}
+
+	var entry contentsEntry
+	if err := json.Unmarshal(raw, &entry); err != nil {
+		return nil, fmt.Errorf("%w: invalid response: %v", ErrGiteaAPIFailure, err)
+	}
+	if entry.Type != "file" {
+		return nil, fmt.Errorf("%w: %w: %s is a %s", ErrGiteaAPIFailure, restapi.ErrNotFound, filePath, entry.Type)
+	}
+	return restapi.DecodeContent(entry.Encoding, entry.Content)
+}
+
+// escapePath escapes the segments of a slash-separated path
+func escapePath(p string) string {
+	segments := strings.Split(strings.Trim(p, "/"), "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+// ReadFile reads a file by its path relative to the repository root
+func (r *GiteaRepository) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	return r.api.ReadFile(ctx, name, r.getFileContent)
+}
+
+// CheckHealth checks that the Gitea repository is reachable
+func (r *GiteaRepository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name: "gitea",
+		Details: map[string]any{
+			"repository": r.config.Owner + "/" + r.config.Repo,
+			"path":       r.config.Path,
+		},
+	}
+
+	return r.api.CheckHealth(ctx, check)
+}
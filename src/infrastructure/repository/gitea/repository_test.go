@@ -0,0 +1,198 @@
+package gitea
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"path"
+	"sort"
+	"strings"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitea is a local fake of the Gitea contents API serving in-memory files of a single repository at a single ref
+type fakeGitea struct {
+	t      *testing.T
+	ref    string
+	token  string
+	files  map[string]string
+	status int
+}
+
+func (f *fakeGitea) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, "token "+f.token, r.Header.Get("Authorization"))
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	endpoint, ok := strings.CutPrefix(r.URL.Path, "/api/v1/repos/writers/blog")
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if endpoint == "" {
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1})
+		return
+	}
+
+	contentsPath, ok := strings.CutPrefix(endpoint, "/contents/")
+	if !ok || r.URL.Query().Get("ref") != f.ref {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	if content, ok := f.files[contentsPath]; ok {
+		_ = json.NewEncoder(w).Encode(contentsEntry{
+			Name:     path.Base(contentsPath),
+			Path:     contentsPath,
+			Type:     "file",
+			Encoding: "base64",
+			Content:  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+		return
+	}
+
+	// List the files and directories directly below the path
+	seen := make(map[string]bool)
+	entries := []contentsEntry{}
+	for filePath := range f.files {
+		rest, ok := strings.CutPrefix(filePath, contentsPath+"/")
+		if !ok {
+			continue
+		}
+		name, _, isDir := strings.Cut(rest, "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := contentsEntry{Name: name, Path: contentsPath + "/" + name, Type: "file"}
+		if isDir {
+			entry.Type = "dir"
+		}
+		entries = append(entries, entry)
+	}
+	if len(entries) == 0 {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+	_ = json.NewEncoder(w).Encode(entries)
+}
+
+func newFakeGitea() *fakeGitea {
+	return &fakeGitea{
+		ref:   "main",
+		token: "gitea-secret",
+		files: map[string]string{
+			"posts/20240516-good.md":    "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n",
+			"posts/20240517-broken.md":  "---\ntitle: Broken\ndate: 17.05.2024\n---\n{{< vimeo 1 >}}\n",
+			"posts/20240518-warning.md": "---\ntitle: Warning\n---\nHello!\n",
+			"posts/notes.txt":           "not a post",
+			"posts/drafts/draft.md":     "---\ntitle: Draft\ndate: 19.05.2024\n---\nHello!\n",
+			"examples/main.go":          "package main\n",
+		},
+	}
+}
+
+func newTestRepository(t *testing.T, fake *fakeGitea) *GiteaRepository {
+	fake.t = t
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	repository, err := NewGiteaRepository(markdown.NewGoldmarkParser(), NewConfig(server.URL, "writers", "blog", "posts", fake.ref, fake.token))
+	assert.NoError(t, err)
+	return repository
+}
+
+func TestGiteaRepository_FetchPosts(t *testing.T) {
+	repository := newTestRepository(t, newFakeGitea())
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, 2, report.Posts)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, "20240517-broken.md", report.Errors[0].Filename)
+	assert.Equal(t, blog.LoadErrorParse, report.Errors[0].Kind)
+	assert.Equal(t, []blog.LoadWarning{{
+		Filename: "20240518-warning.md",
+		Message:  `frontmatter field "date" is required`,
+	}}, report.Warnings)
+}
+
+func TestGiteaRepository_FetchPosts_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, err: blog.ErrRateLimited},
+		{name: "server error", status: http.StatusBadGateway, err: ErrGiteaAPIFailure},
+		{name: "unauthorized", status: http.StatusUnauthorized, err: ErrGiteaAPIFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeGitea()
+			fake.status = tt.status
+
+			_, _, err := newTestRepository(t, fake).FetchPosts(context.Background())
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGiteaRepository_ReadFile(t *testing.T) {
+	repository := newTestRepository(t, newFakeGitea())
+
+	content, err := repository.ReadFile(context.Background(), "examples/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+
+	_, err = repository.ReadFile(context.Background(), "examples/missing.go")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "posts/drafts")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "../outside.go")
+	assert.ErrorIs(t, err, blog.ErrInvalidPath)
+}
+
+func TestGiteaRepository_CheckHealth(t *testing.T) {
+	fake := newFakeGitea()
+	repository := newTestRepository(t, fake)
+
+	checks := repository.CheckHealth(context.Background())
+	assert.Len(t, checks, 1)
+	assert.True(t, checks[0].Healthy)
+
+	fake.status = http.StatusUnauthorized
+	checks = repository.CheckHealth(context.Background())
+	assert.False(t, checks[0].Healthy)
+}
+
+func TestNewGiteaRepository_InvalidConfig(t *testing.T) {
+	parser := markdown.NewGoldmarkParser()
+
+	_, err := NewGiteaRepository(nil, NewConfig("https://gitea.example.com", "writers", "blog", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGiteaRepository(parser, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGiteaRepository(parser, NewConfig("https://gitea.example.com", "", "blog", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGiteaRepository(parser, NewConfig("", "writers", "blog", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
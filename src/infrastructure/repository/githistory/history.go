@@ -0,0 +1,98 @@
+package githistory
+
+import (
+	"slices"
+	"strings"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/plumbing/storer"
+)
+
+// Walk walks the history of the commit once, newest first, for the revisions of each path.
+// A merge only changes a file that differs from every parent, and a deleted file ends its history.
+func Walk(repository *gogit.Repository, from plumbing.Hash, paths []string) (map[string][]blog.Revision, error) {
+	histories := make(map[string][]blog.Revision, len(paths))
+	// done holds the paths deleted before their last change, older commits belong to a previous file
+	done := make(map[string]bool)
+
+	commits, err := repository.Log(&gogit.LogOptions{From: from, Order: gogit.LogOrderCommitterTime})
+	if err != nil {
+		return nil, err
+	}
+	defer commits.Close()
+
+	err = commits.ForEach(func(c *object.Commit) error {
+		tree, err := c.Tree()
+		if err != nil {
+			return err
+		}
+
+		// A root commit is compared with the empty tree
+		parentTrees := []*object.Tree{nil}
+		if c.NumParents() > 0 {
+			parentTrees = parentTrees[:0]
+			err := c.Parents().ForEach(func(parent *object.Commit) error {
+				parentTree, err := parent.Tree()
+				parentTrees = append(parentTrees, parentTree)
+				return err
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, filePath := range paths {
+			if done[filePath] {
+				continue
+			}
+			// A merge only changes a file that differs from every parent, otherwise the change is the parent's
+			hash := entryHash(tree, filePath)
+			if slices.ContainsFunc(parentTrees, func(parentTree *object.Tree) bool {
+				return entryHash(parentTree, filePath) == hash
+			}) {
+				continue
+			}
+			if hash.IsZero() {
+				done[filePath] = true
+				continue
+			}
+
+			histories[filePath] = append(histories[filePath], newRevision(c))
+		}
+
+		if len(done) == len(paths) {
+			return storer.ErrStop
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return histories, nil
+}
+
+// entryHash returns the hash of the path in the tree, the zero hash when the tree has no such path
+func entryHash(tree *object.Tree, filePath string) plumbing.Hash {
+	if tree == nil {
+		return plumbing.ZeroHash
+	}
+	entry, err := tree.FindEntry(filePath)
+	if err != nil {
+		return plumbing.ZeroHash
+	}
+	return entry.Hash
+}
+
+// newRevision creates the revision of a commit, summarized by the first line of its message
+func newRevision(commit *object.Commit) blog.Revision {
+	summary, _, _ := strings.Cut(commit.Message, "\n")
+	return blog.Revision{
+		SHA:     commit.Hash.String(),
+		Date:    commit.Committer.When.UTC(),
+		Author:  commit.Author.Name,
+		Message: strings.TrimSpace(summary),
+	}
+}
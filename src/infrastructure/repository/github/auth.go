@@ -0,0 +1,34 @@
+package github
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+)
+
+// AppConfig holds the credentials of a GitHub App installation
+type AppConfig struct {
+	// AppID is the ID of the GitHub App
+	AppID int64
+
+	// InstallationID is the ID of the installation of the app on the repository owner
+	InstallationID int64
+
+	// PrivateKey is the PEM encoded private key of the app
+	PrivateKey []byte
+}
+
+// newAppTransport creates a transport authenticating as the app installation.
+// It mints installation tokens, caching them until shortly before they expire.
+func newAppTransport(base http.RoundTripper, app *AppConfig) (*ghinstallation.Transport, error) {
+	if app.AppID <= 0 || app.InstallationID <= 0 || len(app.PrivateKey) == 0 {
+		return nil, fmt.Errorf("%w: app ID, installation ID and private key are required", ErrInvalidConfig)
+	}
+
+	transport, err := ghinstallation.New(base, app.AppID, app.InstallationID, app.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: invalid app private key: %v", ErrInvalidConfig, err)
+	}
+	return transport, nil
+}
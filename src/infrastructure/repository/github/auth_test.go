@@ -0,0 +1,87 @@
+package github
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/json"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+// generatePrivateKey returns a PEM encoded RSA key for signing app JWTs
+func generatePrivateKey(t *testing.T) []byte {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	return pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func TestGitHubRepository_AppAuthentication(t *testing.T) {
+	var tokenRequests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v3/app/installations/42/access_tokens":
+			tokenRequests.Add(1)
+			assert.Equal(t, http.MethodPost, r.Method)
+			assert.Contains(t, r.Header.Get("Authorization"), "Bearer ")
+			w.WriteHeader(http.StatusCreated)
+			_ = json.NewEncoder(w).Encode(map[string]any{
+				"token":      "ghs_installation",
+				"expires_at": time.Now().Add(time.Hour),
+			})
+		case "/api/v3/repos/owner/repo/contents/hello.txt":
+			assert.Equal(t, "token ghs_installation", r.Header.Get("Authorization"))
+			_, _ = w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "aGVsbG8="}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	config := NewConfig("owner", "repo", "posts", "")
+	config.App = &AppConfig{AppID: 1, InstallationID: 42, PrivateKey: generatePrivateKey(t)}
+	config.BaseURL = server.URL
+
+	repository, err := NewGitHubRepository(markdown.NewGoldmarkParser(), config)
+	assert.NoError(t, err)
+
+	for range 2 {
+		content, err := repository.ReadFile(context.Background(), "hello.txt")
+		assert.NoError(t, err)
+		assert.Equal(t, "hello", string(content))
+	}
+
+	// The installation token is cached until it is about to expire
+	assert.Equal(t, int32(1), tokenRequests.Load())
+}
+
+func TestNewGitHubRepository_InvalidAppConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		app  *AppConfig
+	}{
+		{name: "missing app ID", app: &AppConfig{InstallationID: 42, PrivateKey: []byte("key")}},
+		{name: "missing installation ID", app: &AppConfig{AppID: 1, PrivateKey: []byte("key")}},
+		{name: "missing private key", app: &AppConfig{AppID: 1, InstallationID: 42}},
+		{name: "invalid private key", app: &AppConfig{AppID: 1, InstallationID: 42, PrivateKey: []byte("not a key")}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig("owner", "repo", "posts", "")
+			config.App = tt.app
+
+			_, err := NewGitHubRepository(markdown.NewGoldmarkParser(), config)
+
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/bradleyfalzon/ghinstallation/v2"
+	"github.com/google/go-github/v70/github"
+)
+
+// newClient creates a GitHub client for the configured API, authenticated as the app installation when configured,
+// with the personal access token otherwise, and anonymously without either
+func newClient(config *Config) (*github.Client, error) {
+	httpClient, err := newHTTPClient(config)
+	if err != nil {
+		return nil, err
+	}
+
+	var appTransport *ghinstallation.Transport
+	if config.App != nil {
+		if appTransport, err = newAppTransport(httpClient.Transport, config.App); err != nil {
+			return nil, err
+		}
+		httpClient.Transport = appTransport
+	}
+
+	client := github.NewClient(httpClient)
+	if config.BaseURL != "" {
+		uploadURL := config.UploadURL
+		if uploadURL == "" {
+			uploadURL = config.BaseURL
+		}
+		if client, err = client.WithEnterpriseURLs(config.BaseURL, uploadURL); err != nil {
+			return nil, fmt.Errorf("%w: invalid enterprise URL: %v", ErrInvalidConfig, err)
+		}
+	}
+
+	switch {
+	case appTransport != nil:
+		// Installation tokens are minted by the same API the posts are fetched from
+		appTransport.BaseURL = strings.TrimSuffix(client.BaseURL.String(), "/")
+	case config.Token != "":
+		client = client.WithAuthToken(config.Token)
+	}
+
+	return client, nil
+}
+
+// newHTTPClient returns a copy of the configured HTTP client, or a new one, trusting the custom CA when configured
+func newHTTPClient(config *Config) (*http.Client, error) {
+	httpClient := &http.Client{}
+	if config.HTTPClient != nil {
+		*httpClient = *config.HTTPClient
+	}
+
+	transport := httpClient.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+
+	if len(config.CACert) > 0 {
+		base, ok := transport.(*http.Transport)
+		if !ok {
+			return nil, fmt.Errorf("%w: a custom CA requires an *http.Transport", ErrInvalidConfig)
+		}
+
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if !pool.AppendCertsFromPEM(config.CACert) {
+			return nil, fmt.Errorf("%w: CA certificate contains no PEM certificates", ErrInvalidConfig)
+		}
+
+		cloned := base.Clone()
+		if cloned.TLSClientConfig == nil {
+			cloned.TLSClientConfig = &tls.Config{MinVersion: tls.VersionTLS12}
+		}
+		cloned.TLSClientConfig.RootCAs = pool
+		transport = cloned
+	}
+
+	httpClient.Transport = transport
+	return httpClient, nil
+}
@@ -0,0 +1,114 @@
+package github
+
+import (
+	"context"
+	"encoding/pem"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewGitHubRepository_EnterpriseURLs(t *testing.T) {
+	tests := []struct {
+		name      string
+		baseURL   string
+		uploadURL string
+		expected  string
+		upload    string
+	}{
+		{name: "github.com", expected: "https://api.github.com/", upload: "https://uploads.github.com/"},
+		{
+			name:     "enterprise host",
+			baseURL:  "https://github.example.com",
+			expected: "https://github.example.com/api/v3/",
+			upload:   "https://github.example.com/api/uploads/",
+		},
+		{
+			name:      "enterprise API and upload URLs",
+			baseURL:   "https://github.example.com/api/v3/",
+			uploadURL: "https://uploads.example.com/api/uploads/",
+			expected:  "https://github.example.com/api/v3/",
+			upload:    "https://uploads.example.com/api/uploads/",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig("owner", "repo", "posts", "")
+			config.BaseURL = tt.baseURL
+			config.UploadURL = tt.uploadURL
+
+			repository, err := NewGitHubRepository(markdown.NewGoldmarkParser(), config)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, repository.client.BaseURL.String())
+			assert.Equal(t, tt.upload, repository.client.UploadURL.String())
+		})
+	}
+}
+
+func TestNewGitHubRepository_CustomCA(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v3/repos/owner/repo/contents/hello.txt", r.URL.Path)
+		_, _ = w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "aGVsbG8="}`))
+	}))
+	defer server.Close()
+
+	caCert := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: server.Certificate().Raw})
+
+	tests := []struct {
+		name    string
+		caCert  []byte
+		trusted bool
+	}{
+		{name: "trusted CA", caCert: caCert, trusted: true},
+		{name: "system roots only", caCert: nil, trusted: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig("owner", "repo", "posts", "")
+			config.BaseURL = server.URL
+			config.CACert = tt.caCert
+
+			repository, err := NewGitHubRepository(markdown.NewGoldmarkParser(), config, WithRetryPolicy(RetryPolicy{MaxAttempts: 1}))
+			assert.NoError(t, err)
+
+			content, err := repository.ReadFile(context.Background(), "hello.txt")
+			if !tt.trusted {
+				assert.ErrorIs(t, err, ErrGitHubAPIFailure)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, "hello", string(content))
+		})
+	}
+}
+
+func TestNewGitHubRepository_InvalidClientConfig(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(config *Config)
+	}{
+		{name: "invalid CA", modify: func(config *Config) { config.CACert = []byte("not a certificate") }},
+		{name: "CA with a custom transport", modify: func(config *Config) {
+			config.CACert = []byte("not a certificate")
+			config.HTTPClient = &http.Client{Transport: http.NewFileTransport(http.Dir("."))}
+		}},
+		{name: "invalid base URL", modify: func(config *Config) { config.BaseURL = "://github.example.com" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := NewConfig("owner", "repo", "posts", "")
+			tt.modify(config)
+
+			_, err := NewGitHubRepository(markdown.NewGoldmarkParser(), config)
+
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
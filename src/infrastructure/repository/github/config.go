@@ -1,5 +1,7 @@
 package github
 
+import "net/http"
+
 // Config holds the configuration for the GitHub repository
 type Config struct {
 	// Owner is the GitHub repository owner
@@ -11,8 +13,27 @@ type Config struct {
 	// Path is the path to the blog posts directory in the repository
 	Path string
 
+	// Ref is the branch, tag or commit the posts are read at, e.g. main or refs/heads/main. Empty uses the default branch.
+	Ref string
+
 	// Token is the GitHub API token
 	Token string
+
+	// App authenticates as a GitHub App installation, taking precedence over the token
+	App *AppConfig
+
+	// BaseURL is the API URL of a GitHub Enterprise server, such as https://github.example.com/api/v3/.
+	// Empty uses api.github.com.
+	BaseURL string
+
+	// UploadURL is the upload URL of a GitHub Enterprise server, defaulting to the BaseURL
+	UploadURL string
+
+	// CACert is a PEM encoded CA certificate trusted in addition to the system roots, for servers with a private CA
+	CACert []byte
+
+	// HTTPClient is the HTTP client of the API requests, defaulting to a client with the default transport
+	HTTPClient *http.Client
 }
 
 // NewConfig creates a new Config instance with the given parameters
@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/google/go-github/v70/github"
+)
+
+// maxRevisions is the number of revisions listed per post, the most a GraphQL connection returns at once
+const maxRevisions = 100
+
+// historyBatchSize is the number of posts whose history is listed by a single GraphQL request
+const historyBatchSize = 50
+
+// cachedHistory is the revisions of a post with the SHA of the blob they were listed for.
+// The history of a file only changes with its content, so the revisions are reused while the SHA is unchanged.
+type cachedHistory struct {
+	sha       string
+	revisions []blog.Revision
+}
+
+// graphQLRequest is the body of a GraphQL request
+type graphQLRequest struct {
+	Query     string         `json:"query"`
+	Variables map[string]any `json:"variables"`
+}
+
+// historyResponse is the response of the history query, the history connections keyed by their path alias
+type historyResponse struct {
+	Data struct {
+		Repository struct {
+			Object map[string]*struct {
+				Nodes []historyCommit `json:"nodes"`
+			} `json:"object"`
+		} `json:"repository"`
+	} `json:"data"`
+	Errors []struct {
+		Type    string `json:"type"`
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+// historyCommit is a commit of the history of a post
+type historyCommit struct {
+	OID           string    `json:"oid"`
+	URL           string    `json:"url"`
+	Message       string    `json:"message"`
+	CommittedDate time.Time `json:"committedDate"`
+	Author        struct {
+		Name string `json:"name"`
+	} `json:"author"`
+}
+
+// getHistories lists the commits changing each post, newest first, keyed by filename.
+// Only the posts whose blob changed since their history was cached are listed, historyBatchSize posts per request.
+// On failure the histories listed so far are returned with the error.
+func (r *GitHubRepository) getHistories(ctx context.Context, files []*github.RepositoryContent) (map[string][]blog.Revision, error) {
+	histories := make(map[string][]blog.Revision, len(files))
+
+	var changed []*github.RepositoryContent
+	r.historyMu.Lock()
+	for _, file := range files {
+		cached, ok := r.histories[r.config.Path+"/"+file.GetName()]
+		if ok && file.GetSHA() != "" && cached.sha == file.GetSHA() {
+			histories[file.GetName()] = cached.revisions
+			continue
+		}
+		changed = append(changed, file)
+	}
+	r.historyMu.Unlock()
+
+	for batch := range slices.Chunk(changed, historyBatchSize) {
+		paths := make([]string, 0, len(batch))
+		for _, file := range batch {
+			paths = append(paths, r.config.Path+"/"+file.GetName())
+		}
+
+		revisions, err := r.listHistories(ctx, paths)
+		if err != nil {
+			return histories, err
+		}
+
+		r.historyMu.Lock()
+		for i, file := range batch {
+			histories[file.GetName()] = revisions[i]
+			r.histories[paths[i]] = cachedHistory{sha: file.GetSHA(), revisions: revisions[i]}
+		}
+		r.historyMu.Unlock()
+	}
+
+	return histories, nil
+}
+
+// listHistories lists the commits changing each path at the configured ref with a single GraphQL request,
+// in the order of the paths
+func (r *GitHubRepository) listHistories(ctx context.Context, paths []string) ([][]blog.Revision, error) {
+	ref := r.config.Ref
+	if ref == "" {
+		ref = "HEAD"
+	}
+	variables := map[string]any{
+		"owner": r.config.Owner,
+		"name":  r.config.Repo,
+		"ref":   ref,
+	}
+	for i, filePath := range paths {
+		variables[fmt.Sprintf("path%d", i)] = filePath
+	}
+
+	var response historyResponse
+	err := r.withRetry(ctx, "list commits", func() (*github.Response, error) {
+		// The GraphQL endpoint is /graphql on api.github.com and /api/graphql next to /api/v3 on GitHub Enterprise
+		req, err := r.client.NewRequest(http.MethodPost, "../graphql", graphQLRequest{
+			Query:     historyQuery(len(paths)),
+			Variables: variables,
+		})
+		if err != nil {
+			return nil, err
+		}
+		response = historyResponse{}
+		return r.client.Do(ctx, req, &response)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(response.Errors) > 0 {
+		if response.Errors[0].Type == "RATE_LIMITED" {
+			return nil, fmt.Errorf("%w: %s", blog.ErrRateLimited, response.Errors[0].Message)
+		}
+		return nil, fmt.Errorf("%w: %s", ErrGitHubAPIFailure, response.Errors[0].Message)
+	}
+	if response.Data.Repository.Object == nil {
+		return nil, fmt.Errorf("%w: %s of %s/%s not found", ErrGitHubAPIFailure, ref, r.config.Owner, r.config.Repo)
+	}
+
+	histories := make([][]blog.Revision, len(paths))
+	for i, filePath := range paths {
+		history := response.Data.Repository.Object[fmt.Sprintf("path%d", i)]
+		if history == nil {
+			continue
+		}
+		for _, commit := range history.Nodes {
+			histories[i] = append(histories[i], newRevision(commit, filePath))
+		}
+	}
+	return histories, nil
+}
+
+// historyQuery is the GraphQL query of the history of count paths, each an aliased history connection of the ref
+func historyQuery(count int) string {
+	var query strings.Builder
+	query.WriteString("query($owner: String!, $name: String!, $ref: String!")
+	for i := range count {
+		fmt.Fprintf(&query, ", $path%d: String!", i)
+	}
+	query.WriteString(") { repository(owner: $owner, name: $name) { object(expression: $ref) { ... on Commit {")
+	for i := range count {
+		fmt.Fprintf(&query, " path%d: history(first: %d, path: $path%d) { nodes { ...revision } }", i, maxRevisions, i)
+	}
+	query.WriteString(" } } } } fragment revision on Commit { oid url message committedDate author { name } }")
+	return query.String()
+}
+
+// newRevision creates the revision of a commit, linking to the diff of the file in the commit
+func newRevision(commit historyCommit, filePath string) blog.Revision {
+	summary, _, _ := strings.Cut(commit.Message, "\n")
+
+	revision := blog.Revision{
+		SHA:     commit.OID,
+		Date:    commit.CommittedDate.UTC(),
+		Author:  commit.Author.Name,
+		Message: strings.TrimSpace(summary),
+	}
+	if commit.URL != "" {
+		// GitHub anchors the diff of a file in a commit by the SHA-256 of its path
+		digest := sha256.Sum256([]byte(filePath))
+		revision.URL = commit.URL + "#diff-" + hex.EncodeToString(digest[:])
+	}
+	return revision
+}
@@ -0,0 +1,190 @@
+package github
+
+import (
+	"cmp"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+// historyServer fakes the contents and GraphQL APIs for the posts at a ref
+type historyServer struct {
+	posts    []string
+	ref      string
+	sha      atomic.Value
+	status   int
+	requests atomic.Int32
+}
+
+func newHistoryServer(posts ...string) *historyServer {
+	server := &historyServer{posts: posts}
+	server.sha.Store("blob1")
+	return server
+}
+
+func (s *historyServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if strings.HasPrefix(r.URL.Path, "/api/v3/") && r.URL.Query().Get("ref") != s.ref {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case r.URL.Path == "/api/v3/repos/owner/repo/contents/posts":
+		var files []map[string]string
+		for _, name := range s.posts {
+			files = append(files, map[string]string{"type": "file", "name": name, "path": "posts/" + name, "sha": s.sha.Load().(string)})
+		}
+		_ = json.NewEncoder(w).Encode(files)
+	case strings.HasPrefix(r.URL.Path, "/api/v3/repos/owner/repo/contents/posts/"):
+		name := path.Base(r.URL.Path)
+		if !slices.Contains(s.posts, name) {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		content := "---\ntitle: Hello\ndate: 16.05.2024\n---\nHello!\n"
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"type":     "file",
+			"name":     name,
+			"sha":      s.sha.Load().(string),
+			"encoding": "base64",
+			"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	case r.URL.Path == "/api/graphql":
+		s.requests.Add(1)
+		if s.status != 0 {
+			w.WriteHeader(s.status)
+			return
+		}
+		var request graphQLRequest
+		_ = json.NewDecoder(r.Body).Decode(&request)
+		if ref := cmp.Or(s.ref, "HEAD"); request.Variables["ref"] != ref {
+			_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": map[string]any{"object": nil}}})
+			return
+		}
+
+		// Every post has the same two commits
+		object := make(map[string]any)
+		for alias := range request.Variables {
+			if strings.HasPrefix(alias, "path") && strings.Contains(request.Query, alias+": history") {
+				object[alias] = map[string]any{"nodes": []map[string]any{
+					{"oid": "c2", "url": "https://github.com/owner/repo/commit/c2", "message": "Fix typo\n\nDetails",
+						"committedDate": "2024-06-01T12:00:00Z", "author": map[string]string{"name": "bob"}},
+					{"oid": "c1", "url": "https://github.com/owner/repo/commit/c1", "message": "Add hello",
+						"committedDate": "2024-05-16T10:00:00Z", "author": map[string]string{"name": "alice"}},
+				}}
+			}
+		}
+		_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"repository": map[string]any{"object": object}}})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+func TestGitHubRepository_FetchPosts_Revisions(t *testing.T) {
+	server := newHistoryServer("20240516-hello.md")
+	repository, _ := newTestRepository(t, server.ServeHTTP)
+
+	posts, _, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	post := posts[0]
+	assert.Equal(t, time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC), post.UpdatedAt)
+	assert.Equal(t, []string{"alice", "bob"}, post.Authors)
+	assert.Equal(t, "alice", post.Author)
+	assert.Equal(t, []blog.Revision{
+		{
+			SHA:     "c2",
+			Date:    time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC),
+			Author:  "bob",
+			Message: "Fix typo",
+			URL:     "https://github.com/owner/repo/commit/c2#diff-2ff0bfe43bd2d0f48eb6e579f633b7cc31eba64c94b2be130c1731600b622c9b",
+		},
+		{
+			SHA:     "c1",
+			Date:    time.Date(2024, 5, 16, 10, 0, 0, 0, time.UTC),
+			Author:  "alice",
+			Message: "Add hello",
+			URL:     "https://github.com/owner/repo/commit/c1#diff-2ff0bfe43bd2d0f48eb6e579f633b7cc31eba64c94b2be130c1731600b622c9b",
+		},
+	}, post.Revisions)
+	assert.Equal(t, int32(1), server.requests.Load())
+
+	// The revisions are cached while the content of the post is unchanged
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(1), server.requests.Load())
+
+	server.sha.Store("blob2")
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), server.requests.Load())
+}
+
+func TestGitHubRepository_FetchPosts_RevisionsFailure(t *testing.T) {
+	server := newHistoryServer("20240516-hello.md")
+	server.status = http.StatusNotFound
+	repository, _ := newTestRepository(t, server.ServeHTTP)
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	// The post is served without its history
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Empty(t, posts[0].Revisions)
+	assert.Len(t, report.Warnings, 1)
+	assert.Contains(t, report.Warnings[0].Message, "failed to list revisions")
+
+	// Unless GitHub rate limits the API
+	server.status = http.StatusTooManyRequests
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.ErrorIs(t, err, blog.ErrRateLimited)
+}
+
+func TestGitHubRepository_FetchPosts_RevisionsBatched(t *testing.T) {
+	var posts []string
+	for i := range historyBatchSize + 10 {
+		posts = append(posts, fmt.Sprintf("post-%02d.md", i))
+	}
+	server := newHistoryServer(posts...)
+	repository, _ := newTestRepository(t, server.ServeHTTP)
+
+	fetched, _, err := repository.FetchPosts(context.Background())
+
+	// The histories are listed with a request per batch of posts, not per post
+	assert.NoError(t, err)
+	assert.Len(t, fetched, len(posts))
+	for _, post := range fetched {
+		assert.Len(t, post.Revisions, 2, post.Filename)
+	}
+	assert.Equal(t, int32(2), server.requests.Load())
+}
+
+func TestGitHubRepository_FetchPosts_Ref(t *testing.T) {
+	server := newHistoryServer("20240516-hello.md")
+	server.ref = "refs/heads/drafts"
+	repository, _ := newTestRepository(t, server.ServeHTTP)
+	repository.config.Ref = "refs/heads/drafts"
+
+	// The contents and the history are read at the ref
+	posts, report, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Len(t, posts[0].Revisions, 2)
+	assert.Empty(t, report.Warnings)
+
+	content, err := repository.ReadFile(context.Background(), "posts/20240516-hello.md")
+	assert.NoError(t, err)
+	assert.Contains(t, string(content), "title: Hello")
+}
@@ -11,6 +11,9 @@ import (
 	"time"
 
 	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"buyallmemes.com/blog-api/src/infrastructure/metrics"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/pool"
 	"github.com/google/go-github/v70/github"
 )
 
@@ -20,7 +23,7 @@ var (
 	ErrGitHubAPIFailure = errors.New("GitHub API failure")
 	ErrContentDecoding  = errors.New("content decoding failure")
 	ErrMarkdownParsing  = errors.New("markdown parsing failure")
-	ErrContextCancelled = errors.New("context cancelled")
+	ErrContextCancelled = pool.ErrContextCancelled
 )
 
 // GitHubRepository implements the PostRepository interface using the GitHub API
@@ -28,10 +31,39 @@ type GitHubRepository struct {
 	client         *github.Client
 	markdownParser blog.MarkdownParser
 	config         *Config
+	retryPolicy    RetryPolicy
+	logger         *logging.Logger
+	metrics        *metrics.Publisher
+	sleep          func(ctx context.Context, delay time.Duration) error
+
+	// rate is the rate limit reported by the last response
+	rateMu sync.Mutex
+	rate   github.Rate
+
+	// histories caches the revisions of the posts by path
+	historyMu sync.Mutex
+	histories map[string]cachedHistory
+}
+
+// Option configures a GitHubRepository
+type Option func(*GitHubRepository)
+
+// WithLogger sets the logger of retries and the rate limit headroom
+func WithLogger(logger *logging.Logger) Option {
+	return func(r *GitHubRepository) {
+		r.logger = logger
+	}
+}
+
+// WithMetrics sets the publisher of the rate limit metrics
+func WithMetrics(publisher *metrics.Publisher) Option {
+	return func(r *GitHubRepository) {
+		r.metrics = publisher
+	}
 }
 
 // NewGitHubRepository creates a new GitHubRepository instance
-func NewGitHubRepository(markdownParser blog.MarkdownParser, config *Config) (*GitHubRepository, error) {
+func NewGitHubRepository(markdownParser blog.MarkdownParser, config *Config, opts ...Option) (*GitHubRepository, error) {
 	if markdownParser == nil {
 		return nil, fmt.Errorf("%w: markdown parser is required", ErrInvalidConfig)
 	}
@@ -44,27 +76,40 @@ func NewGitHubRepository(markdownParser blog.MarkdownParser, config *Config) (*G
 		return nil, fmt.Errorf("%w: owner, repo, and path are required", ErrInvalidConfig)
 	}
 
-	client := github.NewClient(nil)
-	if config.Token != "" {
-		client = client.WithAuthToken(config.Token)
+	client, err := newClient(config)
+	if err != nil {
+		return nil, err
 	}
 
-	return &GitHubRepository{
+	repository := &GitHubRepository{
 		client:         client,
 		markdownParser: markdownParser,
 		config:         config,
-	}, nil
+		retryPolicy:    DefaultRetryPolicy(),
+		logger:         logging.Default(),
+		sleep:          sleepContext,
+		histories:      make(map[string]cachedHistory),
+	}
+	for _, opt := range opts {
+		opt(repository)
+	}
+
+	return repository, nil
 }
 
-// FetchPosts fetches all blog posts from GitHub
-func (r *GitHubRepository) FetchPosts(ctx context.Context) ([]blog.Post, error) {
+// FetchPosts fetches all blog posts from GitHub.
+// Posts that fail to load are skipped and listed in the report.
+func (r *GitHubRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
 	// Add timeout to context if not already set
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
 	defer cancel()
+	defer r.reportRateLimit()
 
 	directoryContent, err := r.getDirectoryContent(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get directory content: %w", err)
+		return nil, report, fmt.Errorf("failed to get directory content: %w", err)
 	}
 
 	// Filter markdown files
@@ -76,84 +121,48 @@ func (r *GitHubRepository) FetchPosts(ctx context.Context) ([]blog.Post, error)
 	}
 
 	if len(mdFiles) == 0 {
-		return []blog.Post{}, nil
-	}
-
-	// Use errgroup for better error handling
-	var (
-		mu    sync.Mutex
-		posts []blog.Post
-	)
-
-	// Process files concurrently with a worker pool pattern
-	errChan := make(chan error, 1)
-	resultChan := make(chan blog.Post)
-
-	// Start workers (limit concurrency to 5)
-	const maxWorkers = 5
-	workChan := make(chan *github.RepositoryContent, len(mdFiles))
-
-	// Start a fixed number of workers
-	var wg sync.WaitGroup
-	for i := 0; i < maxWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for file := range workChan {
-				select {
-				case <-ctx.Done():
-					// Context cancelled, stop processing
-					errChan <- fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
-					return
-				default:
-					post, err := r.fetchPost(ctx, file)
-					if err != nil {
-						errChan <- err
-						return
-					}
-					resultChan <- post
-				}
-			}
-		}()
-	}
-
-	// Close work channel when all files are queued
-	go func() {
-		for _, file := range mdFiles {
-			workChan <- file
-		}
-		close(workChan)
-	}()
-
-	// Collect results
-	go func() {
-		wg.Wait()
-		close(resultChan)
-	}()
-
-	// Process results and handle errors
-	for {
-		select {
-		case err := <-errChan:
-			// Cancel context to stop all workers
-			cancel()
-			return nil, err
-		case post, ok := <-resultChan:
-			if !ok {
-				// All results processed
-				return posts, nil
-			}
-			mu.Lock()
-			posts = append(posts, post)
-			mu.Unlock()
-		case <-ctx.Done():
-			return nil, fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
-		}
+		return []blog.Post{}, report, nil
 	}
+
+	// The history of all posts is listed up front, in a request per historyBatchSize posts instead of one per post
+	histories, historyErr := r.getHistories(ctx, mdFiles)
+	if errors.Is(historyErr, blog.ErrRateLimited) {
+		// A partial blog would replace the cached posts, fail the whole load instead
+		return nil, report, fmt.Errorf("failed to list revisions: %w", historyErr)
+	}
+
+	posts, err := pool.Loader[*github.RepositoryContent]{
+		Name: (*github.RepositoryContent).GetName,
+		Load: func(ctx context.Context, file *github.RepositoryContent) (blog.Post, error) {
+			return r.fetchPost(ctx, file, histories, historyErr)
+		},
+		Kind: pool.ErrorKind(ErrContentDecoding, ErrMarkdownParsing),
+	}.LoadPosts(ctx, mdFiles, &report)
+	if err != nil {
+		return nil, report, err
+	}
+
+	return posts, report, nil
+}
+
+// FetchPost fetches a single post from GitHub, returning blog.ErrFileNotFound when it does not exist
+func (r *GitHubRepository) FetchPost(ctx context.Context, filename string) (blog.Post, error) {
+	// Add timeout to context if not already set
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+	defer r.reportRateLimit()
+
+	file := &github.RepositoryContent{Name: &filename}
+	histories, historyErr := r.getHistories(ctx, []*github.RepositoryContent{file})
+	if errors.Is(historyErr, blog.ErrRateLimited) {
+		return blog.Post{}, fmt.Errorf("failed to list revisions: %w", historyErr)
+	}
+	return r.fetchPost(ctx, file, histories, historyErr)
 }
 
-// fetchPost fetches a single post from GitHub
-func (r *GitHubRepository) fetchPost(ctx context.Context, file *github.RepositoryContent) (blog.Post, error) {
+// fetchPost fetches a single post from GitHub, dated and attributed by its revisions in the histories.
+// historyErr is why the histories lack revisions of a post.
+func (r *GitHubRepository) fetchPost(ctx context.Context, file *github.RepositoryContent, histories map[string][]blog.Revision, historyErr error) (blog.Post, error) {
 	if file == nil || file.Name == nil {
 		return blog.Post{}, fmt.Errorf("%w: invalid file", ErrInvalidConfig)
 	}
@@ -172,32 +181,45 @@ func (r *GitHubRepository) fetchPost(ctx context.Context, file *github.Repositor
 		return blog.Post{}, fmt.Errorf("%w: %v", ErrContentDecoding, err)
 	}
 
-	parsed, err := r.markdownParser.ParseMarkdown(string(decoded))
+	parsed, err := r.markdownParser.ParseDocument(ctx, blog.Document{
+		Filename: *file.Name,
+		Content:  string(decoded),
+		Files:    r,
+	})
 	if err != nil {
 		return blog.Post{}, fmt.Errorf("%w: %v", ErrMarkdownParsing, err)
 	}
 
-	return blog.Post{
-		Filename: *file.Name,
-		Content:  parsed.Content,
-		Date:     parsed.Date,
-		Title:    parsed.Title,
-		Anchor:   parsed.Anchor,
-	}, nil
+	post := blog.NewPost(*file.Name, string(decoded), parsed)
+
+	// A post without its history is still served
+	revisions, ok := histories[*file.Name]
+	if !ok && historyErr != nil {
+		post.Warnings = append(post.Warnings, fmt.Sprintf("failed to list revisions: %v", historyErr))
+	}
+	post.SetRevisions(revisions)
+
+	return post, nil
 }
 
 // getDirectoryContent gets the content of a directory from GitHub
 func (r *GitHubRepository) getDirectoryContent(ctx context.Context) ([]*github.RepositoryContent, error) {
-	_, directoryContent, resp, err := r.client.Repositories.GetContents(
-		ctx,
-		r.config.Owner,
-		r.config.Repo,
-		r.config.Path,
-		nil,
-	)
+	var directoryContent []*github.RepositoryContent
+	var resp *github.Response
+	err := r.withRetry(ctx, "list posts", func() (*github.Response, error) {
+		var err error
+		_, directoryContent, resp, err = r.client.Repositories.GetContents(
+			ctx,
+			r.config.Owner,
+			r.config.Repo,
+			r.config.Path,
+			r.contentOptions(),
+		)
+		return resp, err
+	})
 
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrGitHubAPIFailure, err)
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -213,16 +235,25 @@ func (r *GitHubRepository) getPostContent(ctx context.Context, filename *string)
 		return nil, fmt.Errorf("%w: filename is nil", ErrInvalidConfig)
 	}
 
-	content, _, resp, err := r.client.Repositories.GetContents(
-		ctx,
-		r.config.Owner,
-		r.config.Repo,
-		fmt.Sprintf("%s/%s", r.config.Path, *filename),
-		nil,
-	)
-
+	var content *github.RepositoryContent
+	var resp *github.Response
+	err := r.withRetry(ctx, "get post", func() (*github.Response, error) {
+		var err error
+		content, _, resp, err = r.client.Repositories.GetContents(
+			ctx,
+			r.config.Owner,
+			r.config.Repo,
+			fmt.Sprintf("%s/%s", r.config.Path, *filename),
+			r.contentOptions(),
+		)
+		return resp, err
+	})
+
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, *filename)
+	}
 	if err != nil {
-		return nil, fmt.Errorf("%w: %v", ErrGitHubAPIFailure, err)
+		return nil, err
 	}
 
 	if resp.StatusCode != http.StatusOK {
@@ -231,3 +262,65 @@ func (r *GitHubRepository) getPostContent(ctx context.Context, filename *string)
 
 	return content, nil
 }
+
+// contentOptions reads the contents at the configured ref
+func (r *GitHubRepository) contentOptions() *github.RepositoryContentGetOptions {
+	return &github.RepositoryContentGetOptions{Ref: r.config.Ref}
+}
+
+// ReadFile reads a file by its path relative to the repository root, at the configured ref
+func (r *GitHubRepository) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	cleaned, err := blog.CleanPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	var file *github.RepositoryContent
+	var resp *github.Response
+	err = r.withRetry(ctx, "read file", func() (*github.Response, error) {
+		var err error
+		file, _, resp, err = r.client.Repositories.GetContents(ctx, r.config.Owner, r.config.Repo, cleaned, r.contentOptions())
+		return resp, err
+	})
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, cleaned)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if file == nil {
+		return nil, fmt.Errorf("%w: %s is a directory", blog.ErrFileNotFound, cleaned)
+	}
+
+	content, err := file.GetContent()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrContentDecoding, err)
+	}
+	return []byte(content), nil
+}
+
+// CheckHealth checks that the GitHub API is reachable and reports the remaining rate limit
+func (r *GitHubRepository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	check := blog.HealthCheck{Name: "github"}
+
+	limits, _, err := r.client.RateLimit.Get(ctx)
+	if err != nil {
+		check.Message = fmt.Sprintf("%v: %v", ErrGitHubAPIFailure, err)
+		return []blog.HealthCheck{check}
+	}
+
+	core := limits.GetCore()
+	check.Details = map[string]any{
+		"repository":           r.config.Owner + "/" + r.config.Repo,
+		"rate_limit":           core.Limit,
+		"rate_limit_remaining": core.Remaining,
+		"rate_limit_reset":     core.Reset.Time,
+	}
+	if core.Remaining == 0 {
+		check.Message = "rate limit exhausted"
+		return []blog.HealthCheck{check}
+	}
+
+	check.Healthy = true
+	return []blog.HealthCheck{check}
+}
@@ -0,0 +1,23 @@
+package github
+
+import (
+	"context"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGitHubRepository_FetchPost(t *testing.T) {
+	server := newHistoryServer("20240516-hello.md")
+	repository, _ := newTestRepository(t, server.ServeHTTP)
+
+	post, err := repository.FetchPost(context.Background(), "20240516-hello.md")
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", post.Title)
+	assert.Len(t, post.Revisions, 2)
+	assert.Equal(t, int32(1), server.requests.Load())
+
+	_, err = repository.FetchPost(context.Background(), "20240517-missing.md")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+}
@@ -0,0 +1,212 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/metrics"
+	"github.com/google/go-github/v70/github"
+)
+
+// rateLimitWarning is the share of the rate limit below which the remaining requests are logged as a warning
+const rateLimitWarning = 0.1
+
+// RetryPolicy controls how failed GitHub requests are retried
+type RetryPolicy struct {
+	// MaxAttempts is the number of attempts of a request, including the first one
+	MaxAttempts int
+
+	// BaseDelay is the backoff before the first retry, doubled for every further retry
+	BaseDelay time.Duration
+
+	// MaxDelay caps the backoff. Requests that GitHub asks to delay for longer are not retried.
+	MaxDelay time.Duration
+}
+
+// DefaultRetryPolicy returns the retry policy used unless configured otherwise
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 4,
+		BaseDelay:   250 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// backoff returns the exponential backoff before the given retry with equal jitter,
+// so concurrent workers don't retry in lockstep
+func (p RetryPolicy) backoff(retry int) time.Duration {
+	delay := p.BaseDelay << (retry - 1)
+	if delay <= 0 || delay > p.MaxDelay {
+		delay = p.MaxDelay
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+// WithRetryPolicy sets how failed GitHub requests are retried
+func WithRetryPolicy(policy RetryPolicy) Option {
+	return func(r *GitHubRepository) {
+		r.retryPolicy = policy
+	}
+}
+
+// retryDecision describes whether and when a failed request may be retried
+type retryDecision struct {
+	retryable   bool
+	rateLimited bool
+	// wait is the delay GitHub asked for, zero when it didn't ask for one
+	wait time.Duration
+}
+
+// classifyError decides whether a failed request is worth retrying.
+// Rate limits and server errors are retried, other API errors are permanent.
+func classifyError(err error) retryDecision {
+	var rateLimitErr *github.RateLimitError
+	var abuseErr *github.AbuseRateLimitError
+	var responseErr *github.ErrorResponse
+
+	switch {
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		return retryDecision{}
+	case errors.As(err, &rateLimitErr):
+		return retryDecision{retryable: true, rateLimited: true, wait: time.Until(rateLimitErr.Rate.Reset.Time)}
+	case errors.As(err, &abuseErr):
+		return retryDecision{retryable: true, rateLimited: true, wait: abuseErr.GetRetryAfter()}
+	case errors.As(err, &responseErr) && responseErr.Response != nil:
+		status := responseErr.Response.StatusCode
+		return retryDecision{
+			retryable:   status == http.StatusTooManyRequests || status >= http.StatusInternalServerError,
+			rateLimited: status == http.StatusTooManyRequests,
+			wait:        retryAfter(responseErr.Response.Header),
+		}
+	default:
+		// Transport errors such as timeouts and connection resets
+		return retryDecision{retryable: true}
+	}
+}
+
+// retryAfter returns the delay requested by the Retry-After header, falling back to
+// X-RateLimit-Reset when the rate limit is exhausted
+func retryAfter(header http.Header) time.Duration {
+	if value := header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.Atoi(value); err == nil {
+			return time.Duration(seconds) * time.Second
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return time.Until(date)
+		}
+	}
+
+	if header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			return time.Until(time.Unix(reset, 0))
+		}
+	}
+	return 0
+}
+
+// withRetry calls the GitHub API until it succeeds, the error is permanent, the attempts are used up
+// or the next attempt would not fit into the context deadline
+func (r *GitHubRepository) withRetry(ctx context.Context, operation string, call func() (*github.Response, error)) error {
+	for attempt := 1; ; attempt++ {
+		resp, err := call()
+		if resp != nil {
+			r.recordRate(resp.Rate)
+		}
+		if err == nil {
+			return nil
+		}
+
+		decision := classifyError(err)
+		if !decision.retryable || attempt >= r.retryPolicy.MaxAttempts {
+			return wrapAPIError(decision, err)
+		}
+
+		delay := max(r.retryPolicy.backoff(attempt), decision.wait)
+		if delay > r.retryPolicy.MaxDelay {
+			return wrapAPIError(decision, err)
+		}
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) < delay {
+			return wrapAPIError(decision, err)
+		}
+
+		r.logger.Warn("Retrying GitHub request",
+			"operation", operation,
+			"attempt", attempt,
+			"delay", delay,
+			"error", err,
+		)
+		if err := r.sleep(ctx, delay); err != nil {
+			return fmt.Errorf("%w: %v", ErrContextCancelled, err)
+		}
+	}
+}
+
+// wrapAPIError wraps the error of a failed request, marking rate limits so cached posts can be served instead
+func wrapAPIError(decision retryDecision, err error) error {
+	if decision.rateLimited {
+		return fmt.Errorf("%w: %v", blog.ErrRateLimited, err)
+	}
+	return fmt.Errorf("%w: %v", ErrGitHubAPIFailure, err)
+}
+
+// sleepContext waits for the delay or until the context is done
+func sleepContext(ctx context.Context, delay time.Duration) error {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// recordRate keeps the rate limit of the REST API reported by the last response.
+// The history requests draw on the separate points budget of the GraphQL API, which is not recorded.
+func (r *GitHubRepository) recordRate(rate github.Rate) {
+	if rate.Limit == 0 || rate.Resource == "graphql" {
+		return
+	}
+
+	r.rateMu.Lock()
+	defer r.rateMu.Unlock()
+	r.rate = rate
+}
+
+// reportRateLimit publishes the rate limit as metrics and warns when less than rateLimitWarning of it is left
+func (r *GitHubRepository) reportRateLimit() {
+	r.rateMu.Lock()
+	rate := r.rate
+	r.rateMu.Unlock()
+
+	if rate.Limit == 0 {
+		return
+	}
+
+	resetSeconds := max(time.Until(rate.Reset.Time).Seconds(), 0)
+	if r.metrics != nil {
+		err := r.metrics.Publish(map[string]string{"Repository": r.config.Owner + "/" + r.config.Repo},
+			metrics.Metric{Name: "GitHubRateLimit", Unit: metrics.UnitCount, Value: float64(rate.Limit)},
+			metrics.Metric{Name: "GitHubRateLimitRemaining", Unit: metrics.UnitCount, Value: float64(rate.Remaining)},
+			metrics.Metric{Name: "GitHubRateLimitResetSeconds", Unit: metrics.UnitSeconds, Value: resetSeconds},
+		)
+		if err != nil {
+			r.logger.Error("Error publishing rate limit metrics", "error", err)
+		}
+	}
+
+	if float64(rate.Remaining) < float64(rate.Limit)*rateLimitWarning {
+		r.logger.Warn("GitHub rate limit nearly exhausted",
+			"rate_limit", rate.Limit,
+			"rate_limit_remaining", rate.Remaining,
+			"rate_limit_reset", rate.Reset.Time,
+		)
+	}
+}
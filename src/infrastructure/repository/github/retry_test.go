@@ -0,0 +1,166 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/logging"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"buyallmemes.com/blog-api/src/infrastructure/metrics"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestRepository creates a repository talking to the test server, recording its retry delays instead of sleeping
+func newTestRepository(t *testing.T, handler http.HandlerFunc) (*GitHubRepository, *[]time.Duration) {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	config := NewConfig("owner", "repo", "posts", "")
+	config.BaseURL = server.URL
+
+	repository, err := NewGitHubRepository(markdown.NewGoldmarkParser(), config)
+	assert.NoError(t, err)
+
+	var delays []time.Duration
+	repository.sleep = func(_ context.Context, delay time.Duration) error {
+		delays = append(delays, delay)
+		return nil
+	}
+	return repository, &delays
+}
+
+func TestGitHubRepository_ReadFile_RetriesServerErrors(t *testing.T) {
+	var calls atomic.Int32
+	repository, delays := newTestRepository(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4999")
+		_, _ = w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "aGVsbG8="}`))
+	})
+
+	content, err := repository.ReadFile(context.Background(), "hello.txt")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+	assert.Equal(t, int32(3), calls.Load())
+	assert.Len(t, *delays, 2)
+	assert.Equal(t, 4999, repository.rate.Remaining)
+}
+
+func TestGitHubRepository_FetchPosts_RateLimitMetrics(t *testing.T) {
+	remaining := "4999"
+	repository, _ := newTestRepository(t, func(w http.ResponseWriter, _ *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", remaining)
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(30*time.Minute).Unix(), 10))
+		_, _ = w.Write([]byte(`[]`))
+	})
+	var output, logs bytes.Buffer
+	repository.metrics = metrics.NewPublisher(metrics.DefaultNamespace, &output)
+	repository.logger = logging.New(&logging.Config{Level: logging.InfoLevel, Output: &logs})
+
+	_, _, err := repository.FetchPosts(context.Background())
+
+	// Metrics are published on every load, without a log line while enough requests are left
+	assert.NoError(t, err)
+	var document map[string]any
+	assert.NoError(t, json.Unmarshal(output.Bytes(), &document))
+	assert.Equal(t, "owner/repo", document["Repository"])
+	assert.Equal(t, 5000.0, document["GitHubRateLimit"])
+	assert.Equal(t, 4999.0, document["GitHubRateLimitRemaining"])
+	assert.InDelta(t, 1800.0, document["GitHubRateLimitResetSeconds"], 5)
+	assert.Empty(t, logs.String())
+
+	remaining = "10"
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Contains(t, logs.String(), "GitHub rate limit nearly exhausted")
+}
+
+func TestGitHubRepository_ReadFile_DoesNotRetryClientErrors(t *testing.T) {
+	var calls atomic.Int32
+	repository, _ := newTestRepository(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	_, err := repository.ReadFile(context.Background(), "missing.txt")
+
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestGitHubRepository_ReadFile_RespectsRetryAfter(t *testing.T) {
+	var calls atomic.Int32
+	repository, delays := newTestRepository(t, func(w http.ResponseWriter, _ *http.Request) {
+		if calls.Add(1) == 1 {
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{"type": "file", "encoding": "base64", "content": "aGVsbG8="}`))
+	})
+
+	_, err := repository.ReadFile(context.Background(), "hello.txt")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []time.Duration{2 * time.Second}, *delays)
+}
+
+func TestGitHubRepository_ReadFile_RateLimited(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	var calls atomic.Int32
+	repository, delays := newTestRepository(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-RateLimit-Limit", "60")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+	})
+
+	// The reset is past the longest delay, so the request fails right away
+	_, err := repository.ReadFile(context.Background(), "hello.txt")
+
+	assert.ErrorIs(t, err, blog.ErrRateLimited)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, *delays)
+}
+
+func TestGitHubRepository_ReadFile_StaysWithinDeadline(t *testing.T) {
+	var calls atomic.Int32
+	repository, delays := newTestRepository(t, func(w http.ResponseWriter, _ *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "5")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	_, err := repository.ReadFile(ctx, "hello.txt")
+
+	assert.ErrorIs(t, err, ErrGitHubAPIFailure)
+	assert.Equal(t, int32(1), calls.Load())
+	assert.Empty(t, *delays)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseDelay: 100 * time.Millisecond, MaxDelay: time.Second}
+
+	for retry, expected := range map[int]time.Duration{1: 100 * time.Millisecond, 2: 200 * time.Millisecond, 5: time.Second} {
+		delay := policy.backoff(retry)
+		assert.GreaterOrEqual(t, delay, expected/2)
+		assert.LessOrEqual(t, delay, expected)
+	}
+}
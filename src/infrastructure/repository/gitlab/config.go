@@ -0,0 +1,38 @@
+package gitlab
+
+import "net/http"
+
+// DefaultBaseURL is the URL of gitlab.com
+const DefaultBaseURL = "https://gitlab.com"
+
+// Config holds the configuration for the GitLab repository
+type Config struct {
+	// BaseURL is the URL of the GitLab instance, without the /api/v4 suffix
+	BaseURL string
+
+	// Project is the path of the project, such as group/subgroup/project, or its numeric ID
+	Project string
+
+	// Path is the path to the blog posts directory in the project
+	Path string
+
+	// Ref is the branch, tag or commit the posts are read at. Empty uses the default branch.
+	Ref string
+
+	// Token is the GitLab personal, project or group access token
+	Token string
+
+	// HTTPClient is the HTTP client of the API requests, defaulting to http.DefaultClient
+	HTTPClient *http.Client
+}
+
+// NewConfig creates a new Config instance with the given parameters
+func NewConfig(baseURL, project, path, ref, token string) *Config {
+	return &Config{
+		BaseURL: baseURL,
+		Project: project,
+		Path:    path,
+		Ref:     ref,
+		Token:   token,
+	}
+}
@@ -0,0 +1,188 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/pool"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/restapi"
+)
+
+// Common errors
+var (
+	ErrInvalidConfig    = errors.New("invalid repository configuration")
+	ErrGitLabAPIFailure = errors.New("GitLab API failure")
+	ErrContentDecoding  = restapi.ErrContentDecoding
+	ErrMarkdownParsing  = errors.New("markdown parsing failure")
+	ErrContextCancelled = pool.ErrContextCancelled
+)
+
+// GitLabRepository implements the PostRepository interface using the GitLab REST API
+type GitLabRepository struct {
+	api            *restapi.Client
+	markdownParser blog.MarkdownParser
+	config         *Config
+}
+
+// NewGitLabRepository creates a new GitLabRepository instance
+func NewGitLabRepository(markdownParser blog.MarkdownParser, config *Config) (*GitLabRepository, error) {
+	if markdownParser == nil {
+		return nil, fmt.Errorf("%w: markdown parser is required", ErrInvalidConfig)
+	}
+
+	if config == nil {
+		return nil, fmt.Errorf("%w: config is required", ErrInvalidConfig)
+	}
+
+	if config.Project == "" || config.Path == "" {
+		return nil, fmt.Errorf("%w: project and path are required", ErrInvalidConfig)
+	}
+
+	baseURL := config.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	if u, err := url.Parse(baseURL); err != nil || u.Host == "" {
+		return nil, fmt.Errorf("%w: invalid base URL %q", ErrInvalidConfig, baseURL)
+	}
+
+	return &GitLabRepository{
+		api: &restapi.Client{
+			HTTPClient:  config.HTTPClient,
+			BaseURL:     strings.TrimSuffix(baseURL, "/") + "/api/v4/projects/" + url.PathEscape(config.Project),
+			Name:        "GitLab API",
+			Failure:     ErrGitLabAPIFailure,
+			TokenHeader: "PRIVATE-TOKEN",
+			TokenValue:  config.Token,
+			Ref:         config.Ref,
+		},
+		markdownParser: markdownParser,
+		config:         config,
+	}, nil
+}
+
+// treeEntry is an entry of a repository tree listing
+type treeEntry struct {
+	Name string `json:"name"`
+	Type string `json:"type"`
+	Path string `json:"path"`
+}
+
+// file is a repository file with its base64 encoded content
+type file struct {
+	FileName string `json:"file_name"`
+	Encoding string `json:"encoding"`
+	Content  string `json:"content"`
+}
+
+// FetchPosts fetches all blog posts from GitLab.
+// Posts that fail to load are skipped and listed in the report.
+func (r *GitLabRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
+	// Add timeout to context if not already set
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	entries, err := r.getDirectoryContent(ctx)
+	if err != nil {
+		return nil, report, fmt.Errorf("failed to get directory content: %w", err)
+	}
+
+	// Filter markdown files
+	var mdFiles []treeEntry
+	for _, entry := range entries {
+		if entry.Type == "blob" && strings.HasSuffix(entry.Name, ".md") {
+			mdFiles = append(mdFiles, entry)
+		}
+	}
+
+	if len(mdFiles) == 0 {
+		return []blog.Post{}, report, nil
+	}
+
+	posts, err := pool.Loader[treeEntry]{
+		Name: func(entry treeEntry) string { return entry.Name },
+		Load: r.fetchPost,
+		Kind: pool.ErrorKind(ErrContentDecoding, ErrMarkdownParsing),
+	}.LoadPosts(ctx, mdFiles, &report)
+	if err != nil {
+		return nil, report, err
+	}
+
+	return posts, report, nil
+}
+
+// fetchPost fetches a single post from GitLab
+func (r *GitLabRepository) fetchPost(ctx context.Context, entry treeEntry) (blog.Post, error) {
+	content, err := r.getFileContent(ctx, entry.Path)
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("failed to get post content: %w", err)
+	}
+
+	parsed, err := r.markdownParser.ParseDocument(ctx, blog.Document{
+		Filename: entry.Name,
+		Content:  string(content),
+		Files:    r,
+	})
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("%w: %v", ErrMarkdownParsing, err)
+	}
+
+	return blog.NewPost(entry.Name, string(content), parsed), nil
+}
+
+// getDirectoryContent lists the posts directory, following the pages of the listing
+func (r *GitLabRepository) getDirectoryContent(ctx context.Context) ([]treeEntry, error) {
+	query := r.api.RefQuery()
+	query.Set("path", r.config.Path)
+	query.Set("per_page", "100")
+
+	var entries []treeEntry
+	for page := "1"; page != ""; {
+		query.Set("page", page)
+
+		var pageEntries []treeEntry
+		resp, err := r.api.Get(ctx, "/repository/tree", query, &pageEntries)
+		if err != nil {
+			return nil, err
+		}
+
+		entries = append(entries, pageEntries...)
+		page = resp.Header.Get("X-Next-Page")
+	}
+	return entries, nil
+}
+
+// getFileContent gets the decoded content of a file by its path in the project
+func (r *GitLabRepository) getFileContent(ctx context.Context, filePath string) ([]byte, error) {
+	var f file
+	if _, err := r.api.Get(ctx, "/repository/files/"+url.PathEscape(filePath), r.api.RefQuery(), &f); err != nil {
+		return nil, err
+	}
+
+	return restapi.DecodeContent(f.Encoding, f.Content)
+}
+
+// ReadFile reads a file by its path relative to the project root
+func (r *GitLabRepository) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	return r.api.ReadFile(ctx, name, r.getFileContent)
+}
+
+// CheckHealth checks that the GitLab project is reachable
+func (r *GitLabRepository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name: "gitlab",
+		Details: map[string]any{
+			"project": r.config.Project,
+			"path":    r.config.Path,
+		},
+	}
+
+	return r.api.CheckHealth(ctx, check)
+}
@@ -0,0 +1,213 @@
+package gitlab
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeGitLab is a local fake of the GitLab repository API serving in-memory files of a single project at a single ref
+type fakeGitLab struct {
+	t       *testing.T
+	project string
+	ref     string
+	token   string
+	files   map[string]string
+	status  int
+}
+
+func (f *fakeGitLab) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	assert.Equal(f.t, f.token, r.Header.Get("PRIVATE-TOKEN"))
+	if f.status != 0 {
+		w.WriteHeader(f.status)
+		return
+	}
+
+	prefix := "/api/v4/projects/" + url.PathEscape(f.project)
+	endpoint, ok := strings.CutPrefix(r.URL.EscapedPath(), prefix)
+	if !ok {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	if endpoint != "" && r.URL.Query().Get("ref") != f.ref {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+
+	switch {
+	case endpoint == "":
+		_ = json.NewEncoder(w).Encode(map[string]any{"id": 1})
+	case endpoint == "/repository/tree":
+		f.serveTree(w, r)
+	case strings.HasPrefix(endpoint, "/repository/files/"):
+		filePath, _ := url.PathUnescape(strings.TrimPrefix(endpoint, "/repository/files/"))
+		content, ok := f.files[filePath]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(file{
+			FileName: path.Base(filePath),
+			Encoding: "base64",
+			Content:  base64.StdEncoding.EncodeToString([]byte(content)),
+		})
+	default:
+		w.WriteHeader(http.StatusNotFound)
+	}
+}
+
+// serveTree lists the files and directories directly below the path, paginated like GitLab
+func (f *fakeGitLab) serveTree(w http.ResponseWriter, r *http.Request) {
+	dir := r.URL.Query().Get("path")
+	seen := make(map[string]bool)
+	var entries []treeEntry
+	for filePath := range f.files {
+		rest, ok := strings.CutPrefix(filePath, dir+"/")
+		if !ok {
+			continue
+		}
+		name, _, isDir := strings.Cut(rest, "/")
+		if seen[name] {
+			continue
+		}
+		seen[name] = true
+		entry := treeEntry{Name: name, Type: "blob", Path: dir + "/" + name}
+		if isDir {
+			entry.Type = "tree"
+		}
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Name < entries[j].Name })
+
+	page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+	perPage, _ := strconv.Atoi(r.URL.Query().Get("per_page"))
+	// Serve two entries per page regardless of per_page, so the listing spans several pages
+	perPage = min(perPage, 2)
+	start := min((page-1)*perPage, len(entries))
+	end := min(start+perPage, len(entries))
+	if end < len(entries) {
+		w.Header().Set("X-Next-Page", strconv.Itoa(page+1))
+	}
+	_ = json.NewEncoder(w).Encode(entries[start:end])
+}
+
+func newTestRepository(t *testing.T, fake *fakeGitLab) *GitLabRepository {
+	fake.t = t
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	repository, err := NewGitLabRepository(markdown.NewGoldmarkParser(), NewConfig(server.URL, fake.project, "posts", fake.ref, fake.token))
+	assert.NoError(t, err)
+	return repository
+}
+
+func newFakeGitLab() *fakeGitLab {
+	return &fakeGitLab{
+		project: "writers/blog",
+		ref:     "main",
+		token:   "glpat-secret",
+		files: map[string]string{
+			"posts/20240516-good.md":    "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n",
+			"posts/20240517-broken.md":  "---\ntitle: Broken\ndate: 17.05.2024\n---\n{{< vimeo 1 >}}\n",
+			"posts/20240518-warning.md": "---\ntitle: Warning\n---\nHello!\n",
+			"posts/notes.txt":           "not a post",
+			"posts/drafts/draft.md":     "---\ntitle: Draft\ndate: 19.05.2024\n---\nHello!\n",
+			"examples/main.go":          "package main\n",
+		},
+	}
+}
+
+func TestGitLabRepository_FetchPosts(t *testing.T) {
+	repository := newTestRepository(t, newFakeGitLab())
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, 2, report.Posts)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, "20240517-broken.md", report.Errors[0].Filename)
+	assert.Equal(t, blog.LoadErrorParse, report.Errors[0].Kind)
+	assert.Equal(t, []blog.LoadWarning{{
+		Filename: "20240518-warning.md",
+		Message:  `frontmatter field "date" is required`,
+	}}, report.Warnings)
+}
+
+func TestGitLabRepository_FetchPosts_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		err    error
+	}{
+		{name: "rate limited", status: http.StatusTooManyRequests, err: blog.ErrRateLimited},
+		{name: "server error", status: http.StatusBadGateway, err: ErrGitLabAPIFailure},
+		{name: "unauthorized", status: http.StatusUnauthorized, err: ErrGitLabAPIFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fake := newFakeGitLab()
+			fake.status = tt.status
+
+			_, _, err := newTestRepository(t, fake).FetchPosts(context.Background())
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestGitLabRepository_ReadFile(t *testing.T) {
+	repository := newTestRepository(t, newFakeGitLab())
+
+	content, err := repository.ReadFile(context.Background(), "examples/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+
+	_, err = repository.ReadFile(context.Background(), "examples/missing.go")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "../outside.go")
+	assert.ErrorIs(t, err, blog.ErrInvalidPath)
+}
+
+func TestGitLabRepository_CheckHealth(t *testing.T) {
+	fake := newFakeGitLab()
+	repository := newTestRepository(t, fake)
+
+	checks := repository.CheckHealth(context.Background())
+	assert.Len(t, checks, 1)
+	assert.True(t, checks[0].Healthy)
+
+	fake.status = http.StatusUnauthorized
+	checks = repository.CheckHealth(context.Background())
+	assert.False(t, checks[0].Healthy)
+}
+
+func TestNewGitLabRepository_InvalidConfig(t *testing.T) {
+	parser := markdown.NewGoldmarkParser()
+
+	_, err := NewGitLabRepository(nil, NewConfig("", "writers/blog", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGitLabRepository(parser, nil)
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGitLabRepository(parser, NewConfig("", "", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewGitLabRepository(parser, NewConfig("gitlab.example.com", "writers/blog", "posts", "", ""))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
@@ -0,0 +1,105 @@
+package local
+
+import (
+	"fmt"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/githistory"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+)
+
+// gitHistory reads the revisions of the posts from the git checkout the posts directory belongs to
+type gitHistory struct {
+	// mu guards the repository, go-git repositories are not safe for concurrent use
+	mu         sync.Mutex
+	repository *gogit.Repository
+	// postsPath is the slash-separated path of the posts directory in the checkout
+	postsPath string
+
+	// histories caches the revisions of the posts by filename at historyHash, the HEAD they were listed at
+	histories   map[string][]blog.Revision
+	historyHash plumbing.Hash
+}
+
+// openHistory opens the git checkout of the posts directory, returning nil when the posts are not in a checkout
+func openHistory(postsPath string) *gitHistory {
+	absPath, err := filepath.Abs(postsPath)
+	if err != nil {
+		return nil
+	}
+
+	repository, err := gogit.PlainOpenWithOptions(absPath, &gogit.PlainOpenOptions{DetectDotGit: true})
+	if err != nil {
+		return nil
+	}
+	worktree, err := repository.Worktree()
+	if err != nil {
+		return nil
+	}
+	relPath, err := filepath.Rel(worktree.Filesystem.Root(), absPath)
+	if err != nil {
+		return nil
+	}
+
+	return &gitHistory{
+		repository: repository,
+		postsPath:  filepath.ToSlash(relPath),
+	}
+}
+
+// revisions lists the commits changing each post committed at HEAD, newest first, keyed by filename.
+// The history is walked once for all posts and cached until HEAD points to another commit.
+// A checkout without commits has no history.
+func (h *gitHistory) revisions() (map[string][]blog.Revision, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	head, err := h.repository.Head()
+	if err != nil {
+		return nil, nil
+	}
+	if h.historyHash == head.Hash() {
+		return h.histories, nil
+	}
+
+	commit, err := h.repository.CommitObject(head.Hash())
+	if err != nil {
+		return nil, fmt.Errorf("error reading HEAD: %w", err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return nil, fmt.Errorf("error reading HEAD: %w", err)
+	}
+	if h.postsPath != "." {
+		tree, err = tree.Tree(h.postsPath)
+		if err != nil {
+			// No posts are committed yet
+			return nil, nil
+		}
+	}
+
+	var paths []string
+	for _, entry := range tree.Entries {
+		if entry.Mode.IsFile() && strings.HasSuffix(entry.Name, ".md") {
+			paths = append(paths, path.Join(h.postsPath, entry.Name))
+		}
+	}
+
+	histories, err := githistory.Walk(h.repository, head.Hash(), paths)
+	if err != nil {
+		return nil, fmt.Errorf("error reading history of %s: %w", head.Hash(), err)
+	}
+
+	// Key the histories by filename, like the posts
+	byFilename := make(map[string][]blog.Revision, len(histories))
+	for filePath, revisions := range histories {
+		byFilename[path.Base(filePath)] = revisions
+	}
+	h.histories, h.historyHash = byFilename, head.Hash()
+	return byFilename, nil
+}
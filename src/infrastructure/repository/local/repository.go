@@ -2,19 +2,32 @@ package local
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/pool"
+)
+
+// Common errors
+var (
+	ErrMarkdownParsing  = errors.New("markdown parsing failure")
+	ErrContextCancelled = pool.ErrContextCancelled
 )
 
 // LocalRepository implements the PostRepository interface using the local filesystem
 type LocalRepository struct {
 	markdownParser blog.MarkdownParser
 	postsPath      string
+
+	// history reads the revisions of the posts in a git checkout, opened on first use and nil outside a checkout
+	historyMu sync.Mutex
+	history   *gitHistory
 }
 
 // NewLocalRepository creates a new LocalRepository instance
@@ -25,92 +38,92 @@ func NewLocalRepository(markdownParser blog.MarkdownParser, postsPath string) *L
 	}
 }
 
-// FetchPosts fetches all blog posts from the local filesystem
-func (r *LocalRepository) FetchPosts(ctx context.Context) ([]blog.Post, error) {
+// FetchPosts fetches all blog posts from the local filesystem.
+// Posts that fail to load are skipped and listed in the report.
+func (r *LocalRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
 	dir, err := os.ReadDir(r.postsPath)
 	if err != nil {
-		return nil, fmt.Errorf("error reading posts directory: %w", err)
+		return nil, report, fmt.Errorf("error reading posts directory: %w", err)
 	}
 
-	var wg sync.WaitGroup
-	posts := make([]blog.Post, 0)
-	postsChan := make(chan blog.Post)
-	errorsChan := make(chan error, len(dir))
-	done := make(chan bool)
+	// Posts in a git checkout are dated and attributed by their commits
+	histories, historyErr := r.histories()
 
-	// Start a goroutine to collect results
-	go func() {
-		for post := range postsChan {
-			posts = append(posts, post)
-		}
-		done <- true
-	}()
-
-	// Create a buffered channel to act as a job queue
-	jobQueue := make(chan os.DirEntry, len(dir))
-
-	// Launch a fixed number of worker goroutines
-	numWorkers := 5 // Adjust this number based on system capacity
-	for i := 0; i < numWorkers; i++ {
-		wg.Add(1)
-		go func() {
-			defer wg.Done()
-			for file := range jobQueue {
-				post, err := r.fetchPost(file)
-				if err != nil {
-					errorsChan <- err
-					continue
-				}
-				postsChan <- post
-			}
-		}()
-	}
-
-	// Enqueue jobs for workers
+	var mdFiles []os.DirEntry
 	for _, file := range dir {
-		if !strings.HasSuffix(file.Name(), ".md") {
-			continue
+		if strings.HasSuffix(file.Name(), ".md") {
+			mdFiles = append(mdFiles, file)
 		}
-		jobQueue <- file
 	}
-	close(jobQueue)
 
-	// Wait for all goroutines to finish and close channels
-	go func() {
-		wg.Wait()
-		close(postsChan)
-	}()
+	posts, err := pool.Loader[os.DirEntry]{
+		Name: os.DirEntry.Name,
+		Load: func(ctx context.Context, file os.DirEntry) (blog.Post, error) {
+			return r.fetchPost(ctx, file, histories[file.Name()], historyErr)
+		},
+		Kind: loadErrorKind,
+	}.LoadPosts(ctx, mdFiles, &report)
+	if err != nil {
+		return nil, blog.NewLoadReport(report.LoadedAt), err
+	}
 
-	// Wait for either all posts to be collected or an error
-	select {
-	case <-done:
-		return posts, nil
-	case err := <-errorsChan:
-		return nil, err
-	case <-ctx.Done():
-		return nil, ctx.Err()
+	return posts, report, nil
+}
+
+// histories lists the revisions of the posts by filename, none when the posts are not in a git checkout
+func (r *LocalRepository) histories() (map[string][]blog.Revision, error) {
+	r.historyMu.Lock()
+	if r.history == nil {
+		r.history = openHistory(r.postsPath)
 	}
+	history := r.history
+	r.historyMu.Unlock()
+
+	if history == nil {
+		return nil, nil
+	}
+	return history.revisions()
+}
+
+// loadErrorKind classifies the error of a post that failed to load
+func loadErrorKind(err error) blog.LoadErrorKind {
+	if errors.Is(err, ErrMarkdownParsing) {
+		return blog.LoadErrorParse
+	}
+	return blog.LoadErrorFetch
 }
 
-// fetchPost fetches a single post from the local filesystem
-func (r *LocalRepository) fetchPost(file os.DirEntry) (blog.Post, error) {
+// fetchPost fetches a single post from the local filesystem.
+// Posts are dated by the commits changing them, or by the modification time of their file outside a git checkout.
+func (r *LocalRepository) fetchPost(ctx context.Context, file os.DirEntry, revisions []blog.Revision, historyErr error) (blog.Post, error) {
 	content, err := r.getPostContent(file)
 	if err != nil {
 		return blog.Post{}, err
 	}
 
-	parsed, err := r.markdownParser.ParseMarkdown(content)
+	parsed, err := r.markdownParser.ParseDocument(ctx, blog.Document{
+		Filename: file.Name(),
+		Content:  content,
+		Files:    r,
+	})
 	if err != nil {
-		return blog.Post{}, err
+		return blog.Post{}, fmt.Errorf("%w: %v", ErrMarkdownParsing, err)
 	}
 
-	return blog.Post{
-		Filename: file.Name(),
-		Content:  parsed.Content,
-		Date:     parsed.Date,
-		Title:    parsed.Title,
-		Anchor:   parsed.Anchor,
-	}, nil
+	post := blog.NewPost(file.Name(), content, parsed)
+	if historyErr != nil {
+		post.Warnings = append(post.Warnings, fmt.Sprintf("failed to list revisions: %v", historyErr))
+	}
+	post.SetRevisions(revisions)
+	if post.UpdatedAt.IsZero() {
+		if info, err := file.Info(); err == nil {
+			post.UpdatedAt = info.ModTime().UTC()
+		}
+	}
+
+	return post, nil
 }
 
 // getPostContent gets the content of a post from the local filesystem
@@ -122,3 +135,38 @@ func (r *LocalRepository) getPostContent(file os.DirEntry) (string, error) {
 	}
 	return string(fileContent), nil
 }
+
+// ReadFile reads a file by its path relative to the parent of the posts directory,
+// the root of the checkout the posts live in
+func (r *LocalRepository) ReadFile(_ context.Context, name string) ([]byte, error) {
+	cleaned, err := blog.CleanPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	filePath := filepath.Join(filepath.Dir(filepath.Clean(r.postsPath)), filepath.FromSlash(cleaned))
+	content, err := os.ReadFile(filePath)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, cleaned)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading file %s: %w", filePath, err)
+	}
+	return content, nil
+}
+
+// CheckHealth checks that the posts directory is readable
+func (r *LocalRepository) CheckHealth(_ context.Context) []blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name:    "local",
+		Details: map[string]any{"path": r.postsPath},
+	}
+
+	if _, err := os.ReadDir(r.postsPath); err != nil {
+		check.Message = fmt.Sprintf("error reading posts directory: %v", err)
+		return []blog.HealthCheck{check}
+	}
+
+	check.Healthy = true
+	return []blog.HealthCheck{check}
+}
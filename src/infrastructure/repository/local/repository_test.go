@@ -0,0 +1,175 @@
+package local
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	assert.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+	assert.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+}
+
+func TestLocalRepository_FetchPosts_SkipsBrokenPosts(t *testing.T) {
+	root := t.TempDir()
+	postsPath := filepath.Join(root, "posts")
+	writeFile(t, filepath.Join(postsPath, "20240516-good.md"), "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n")
+	writeFile(t, filepath.Join(postsPath, "20240517-broken.md"), "---\ntitle: Broken\ndate: 17.05.2024\n---\n{{< vimeo 1 >}}\n")
+	writeFile(t, filepath.Join(postsPath, "20240518-warning.md"), "---\ntitle: Warning\n---\nHello!\n")
+
+	repository := NewLocalRepository(markdown.NewGoldmarkParser(), postsPath)
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, 2, report.Posts)
+	assert.Len(t, report.Errors, 1)
+	assert.Equal(t, "20240517-broken.md", report.Errors[0].Filename)
+	assert.Equal(t, blog.LoadErrorParse, report.Errors[0].Kind)
+	assert.Contains(t, report.Errors[0].Message, `unknown shortcode "vimeo" at 20240517-broken.md:5`)
+	assert.Equal(t, []blog.LoadWarning{{
+		Filename: "20240518-warning.md",
+		Message:  `frontmatter field "date" is required`,
+	}}, report.Warnings)
+}
+
+func TestLocalRepository_FetchPosts_Cancelled(t *testing.T) {
+	root := t.TempDir()
+	postsPath := filepath.Join(root, "posts")
+	for _, name := range []string{"20240516-a.md", "20240517-b.md", "20240518-c.md", "20240519-d.md", "20240520-e.md", "20240521-f.md"} {
+		writeFile(t, filepath.Join(postsPath, name), "---\ntitle: Post\ndate: 16.05.2024\n---\nHello!\n")
+	}
+
+	repository := NewLocalRepository(markdown.NewGoldmarkParser(), postsPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	posts, report, err := repository.FetchPosts(ctx)
+
+	assert.ErrorIs(t, err, ErrContextCancelled)
+	assert.Nil(t, posts)
+	assert.Zero(t, report.Posts)
+	assert.Empty(t, report.Errors)
+}
+
+func TestLocalRepository_FetchPosts_ModTime(t *testing.T) {
+	postsPath := t.TempDir()
+	filePath := filepath.Join(postsPath, "20240516-good.md")
+	writeFile(t, filePath, "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n")
+	modTime := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	assert.NoError(t, os.Chtimes(filePath, modTime, modTime))
+
+	posts, _, err := NewLocalRepository(markdown.NewGoldmarkParser(), postsPath).FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, modTime, posts[0].UpdatedAt)
+	assert.Empty(t, posts[0].Revisions)
+}
+
+func TestLocalRepository_FetchPosts_GitHistory(t *testing.T) {
+	root := t.TempDir()
+	repository, err := gogit.PlainInit(root, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+
+	commit := func(author, content string, when time.Time) {
+		writeFile(t, filepath.Join(root, "posts", "20240516-good.md"), content)
+		_, err := worktree.Add("posts/20240516-good.md")
+		assert.NoError(t, err)
+		signature := &object.Signature{Name: author, Email: author + "@example.com", When: when}
+		_, err = worktree.Commit("Edit good\n\nDetails", &gogit.CommitOptions{Author: signature, Committer: signature})
+		assert.NoError(t, err)
+	}
+	edited := time.Date(2024, 6, 1, 12, 0, 0, 0, time.UTC)
+	commit("alice", "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n", time.Date(2024, 5, 16, 10, 0, 0, 0, time.UTC))
+	commit("bob", "---\ntitle: Good\ndate: 16.05.2024\n---\nHello again!\n", edited)
+
+	posts, _, err := NewLocalRepository(markdown.NewGoldmarkParser(), filepath.Join(root, "posts")).FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, edited, posts[0].UpdatedAt)
+	assert.Equal(t, []string{"alice", "bob"}, posts[0].Authors)
+	assert.Len(t, posts[0].Revisions, 2)
+	assert.Equal(t, "Edit good", posts[0].Revisions[0].Message)
+	assert.Equal(t, "bob", posts[0].Revisions[0].Author)
+}
+
+func TestLocalRepository_FetchPosts_GitHistoryMerge(t *testing.T) {
+	root := t.TempDir()
+	repository, err := gogit.PlainInit(root, false)
+	assert.NoError(t, err)
+	worktree, err := repository.Worktree()
+	assert.NoError(t, err)
+
+	commit := func(author, file, content string, when time.Time, parents ...plumbing.Hash) plumbing.Hash {
+		writeFile(t, filepath.Join(root, "posts", file), content)
+		_, err := worktree.Add("posts/" + file)
+		assert.NoError(t, err)
+		signature := &object.Signature{Name: author, Email: author + "@example.com", When: when}
+		hash, err := worktree.Commit("Edit "+file, &gogit.CommitOptions{Author: signature, Committer: signature, Parents: parents})
+		assert.NoError(t, err)
+		return hash
+	}
+	edited := "---\ntitle: Good\ndate: 16.05.2024\n---\nHello again!\n"
+	created := commit("alice", "20240516-good.md", "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n", time.Date(2024, 5, 16, 10, 0, 0, 0, time.UTC))
+	branch := commit("bob", "20240516-good.md", edited, time.Date(2024, 5, 17, 10, 0, 0, 0, time.UTC))
+	assert.NoError(t, worktree.Reset(&gogit.ResetOptions{Commit: created, Mode: gogit.HardReset}))
+	main := commit("carol", "20240518-other.md", "---\ntitle: Other\ndate: 18.05.2024\n---\nHello!\n", time.Date(2024, 5, 18, 10, 0, 0, 0, time.UTC))
+	// The merge keeps the version of the branch, it does not change the post itself
+	commit("dave", "20240516-good.md", edited, time.Date(2024, 5, 19, 10, 0, 0, 0, time.UTC), main, branch)
+
+	posts, _, err := NewLocalRepository(markdown.NewGoldmarkParser(), filepath.Join(root, "posts")).FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	sort.Slice(posts, func(i, j int) bool { return posts[i].Filename < posts[j].Filename })
+	assert.Equal(t, []string{"alice", "bob"}, posts[0].Authors)
+	assert.Len(t, posts[0].Revisions, 2)
+	assert.Equal(t, branch.String(), posts[0].Revisions[0].SHA)
+	assert.Equal(t, []string{"carol"}, posts[1].Authors)
+}
+
+func TestLocalRepository_ReadFile(t *testing.T) {
+	root := t.TempDir()
+	writeFile(t, filepath.Join(root, "examples", "main.go"), "package main\n")
+
+	repository := NewLocalRepository(markdown.NewGoldmarkParser(), filepath.Join(root, "posts"))
+
+	content, err := repository.ReadFile(context.Background(), "examples/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+
+	_, err = repository.ReadFile(context.Background(), "examples/missing.go")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "../outside.go")
+	assert.ErrorIs(t, err, blog.ErrInvalidPath)
+}
+
+func TestLocalRepository_CheckHealth(t *testing.T) {
+	postsPath := t.TempDir()
+
+	checks := NewLocalRepository(markdown.NewGoldmarkParser(), postsPath).CheckHealth(context.Background())
+	assert.Len(t, checks, 1)
+	assert.True(t, checks[0].Healthy)
+
+	checks = NewLocalRepository(markdown.NewGoldmarkParser(), filepath.Join(postsPath, "missing")).CheckHealth(context.Background())
+	assert.False(t, checks[0].Healthy)
+	assert.Contains(t, checks[0].Message, "error reading posts directory")
+}
@@ -0,0 +1,162 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"sync"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+)
+
+// ErrInvalidConfig is returned when the sources are misconfigured
+var ErrInvalidConfig = errors.New("invalid repository configuration")
+
+// Source is a named repository the posts are fetched from
+type Source struct {
+	// Name identifies the source, posts are tagged with it
+	Name string
+
+	// Repository fetches the posts of the source
+	Repository blog.PostRepository
+
+	// Optional sources may fail without failing the whole load, they are reported and skipped instead
+	Optional bool
+}
+
+// MultiRepository implements the PostRepository interface by merging the posts of several sources
+type MultiRepository struct {
+	sources []Source
+}
+
+// NewMultiRepository creates a new MultiRepository instance.
+// The sources are listed in priority order, the posts of the first source win anchor conflicts.
+func NewMultiRepository(sources ...Source) (*MultiRepository, error) {
+	if len(sources) == 0 {
+		return nil, fmt.Errorf("%w: at least one source is required", ErrInvalidConfig)
+	}
+
+	names := make(map[string]bool, len(sources))
+	for _, source := range sources {
+		if source.Name == "" || source.Repository == nil {
+			return nil, fmt.Errorf("%w: sources require a name and a repository", ErrInvalidConfig)
+		}
+		if names[source.Name] {
+			return nil, fmt.Errorf("%w: duplicate source %q", ErrInvalidConfig, source.Name)
+		}
+		names[source.Name] = true
+	}
+
+	return &MultiRepository{sources: sources}, nil
+}
+
+// sourceResult is the outcome of fetching the posts of a single source
+type sourceResult struct {
+	posts  []blog.Post
+	report blog.LoadReport
+	err    error
+}
+
+// FetchPosts fetches the posts of all sources concurrently and merges them.
+// Posts whose anchor or alias is already used by a post of a source with a higher priority are skipped,
+// and the failures of optional sources are reported instead of failing the load.
+func (r *MultiRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
+	results := make([]sourceResult, len(r.sources))
+	var wg sync.WaitGroup
+	for i, source := range r.sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			posts, report, err := source.Repository.FetchPosts(ctx)
+			results[i] = sourceResult{posts: posts, report: report, err: err}
+		}()
+	}
+	wg.Wait()
+
+	// owners maps the claimed anchors to the source and file of the post claiming them
+	owners := make(map[string]string)
+	posts := make([]blog.Post, 0)
+	for i, source := range r.sources {
+		result := results[i]
+		if result.err != nil {
+			if !source.Optional {
+				return nil, report, fmt.Errorf("failed to fetch source %s: %w", source.Name, result.err)
+			}
+			report.AddError(source.Name, blog.LoadErrorSource, result.err)
+			continue
+		}
+
+		mergeReport(&report, source.Name, result.report)
+		merged := len(posts)
+		for _, post := range result.posts {
+			post.Source = source.Name
+			filename := path.Join(source.Name, post.Filename)
+			if owner, anchor, ok := claimed(owners, post); ok {
+				err := fmt.Errorf("%w: %q is already used by %s", blog.ErrDuplicateAnchor, anchor, owner)
+				report.AddError(filename, blog.LoadErrorDuplicateAnchor, err)
+				continue
+			}
+			posts = append(posts, post)
+		}
+
+		// Claim the anchors of the source once all its posts are merged, duplicates within a source are
+		// resolved by the service
+		for _, post := range posts[merged:] {
+			for _, anchor := range append([]string{post.Anchor}, post.Aliases...) {
+				if anchor != "" {
+					owners[anchor] = path.Join(source.Name, post.Filename)
+				}
+			}
+		}
+	}
+
+	report.Posts = len(posts)
+	return posts, report, nil
+}
+
+// claimed returns the owner of the first anchor or alias of the post already claimed by another source
+func claimed(owners map[string]string, post blog.Post) (string, string, bool) {
+	for _, anchor := range append([]string{post.Anchor}, post.Aliases...) {
+		if owner, ok := owners[anchor]; ok && anchor != "" {
+			return owner, anchor, true
+		}
+	}
+	return "", "", false
+}
+
+// mergeReport adds the errors and warnings of the report of a source, prefixing their files with its name
+func mergeReport(report *blog.LoadReport, name string, source blog.LoadReport) {
+	for _, loadError := range source.Errors {
+		loadError.Filename = path.Join(name, loadError.Filename)
+		report.Errors = append(report.Errors, loadError)
+	}
+	for _, warning := range source.Warnings {
+		warning.Filename = path.Join(name, warning.Filename)
+		report.Warnings = append(report.Warnings, warning)
+	}
+}
+
+// CheckHealth checks all sources, prefixing the names of their checks with the name of the source.
+// The checks of optional sources are reported healthy, since their failure does not fail the load.
+func (r *MultiRepository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	var checks []blog.HealthCheck
+	for _, source := range r.sources {
+		checker, ok := source.Repository.(blog.HealthChecker)
+		if !ok {
+			continue
+		}
+		for _, check := range checker.CheckHealth(ctx) {
+			check.Name = source.Name + "/" + check.Name
+			if source.Optional && !check.Healthy {
+				check.Healthy = true
+				check.Message = "optional source degraded: " + check.Message
+			}
+			checks = append(checks, check)
+		}
+	}
+	return checks
+}
@@ -0,0 +1,130 @@
+package multi
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+// StubPostRepository is a stub implementation of the PostRepository and HealthChecker interfaces
+type StubPostRepository struct {
+	posts  []blog.Post
+	report blog.LoadReport
+	err    error
+}
+
+func (s *StubPostRepository) FetchPosts(_ context.Context) ([]blog.Post, blog.LoadReport, error) {
+	return s.posts, s.report, s.err
+}
+
+func (s *StubPostRepository) CheckHealth(_ context.Context) []blog.HealthCheck {
+	if s.err != nil {
+		return []blog.HealthCheck{{Name: "stub", Message: s.err.Error()}}
+	}
+	return []blog.HealthCheck{{Name: "stub", Healthy: true}}
+}
+
+func TestMultiRepository_FetchPosts(t *testing.T) {
+	main := &StubPostRepository{
+		posts: []blog.Post{
+			{Filename: "20240516-hello.md", Anchor: "hello"},
+			{Filename: "20240517-moved.md", Anchor: "moved", Aliases: []string{"old"}},
+		},
+		report: blog.LoadReport{
+			Errors:   []blog.LoadError{{Filename: "broken.md", Kind: blog.LoadErrorParse, Message: "bad"}},
+			Warnings: []blog.LoadWarning{{Filename: "20240516-hello.md", Message: "no date"}},
+		},
+	}
+	guest := &StubPostRepository{
+		posts: []blog.Post{
+			{Filename: "20240518-guest.md", Anchor: "guest"},
+			{Filename: "20240519-hello.md", Anchor: "hello"},
+			{Filename: "20240520-old.md", Anchor: "other", Aliases: []string{"old"}},
+		},
+	}
+	repository, err := NewMultiRepository(Source{Name: "main", Repository: main}, Source{Name: "guest", Repository: guest})
+	assert.NoError(t, err)
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, []blog.Post{
+		{Filename: "20240516-hello.md", Source: "main", Anchor: "hello"},
+		{Filename: "20240517-moved.md", Source: "main", Anchor: "moved", Aliases: []string{"old"}},
+		{Filename: "20240518-guest.md", Source: "guest", Anchor: "guest"},
+	}, posts)
+	assert.Equal(t, 3, report.Posts)
+	assert.Equal(t, []blog.LoadError{
+		{Filename: "main/broken.md", Kind: blog.LoadErrorParse, Message: "bad"},
+		{
+			Filename: "guest/20240519-hello.md",
+			Kind:     blog.LoadErrorDuplicateAnchor,
+			Message:  `duplicate anchor: "hello" is already used by main/20240516-hello.md`,
+		},
+		{
+			Filename: "guest/20240520-old.md",
+			Kind:     blog.LoadErrorDuplicateAnchor,
+			Message:  `duplicate anchor: "old" is already used by main/20240517-moved.md`,
+		},
+	}, report.Errors)
+	assert.Equal(t, []blog.LoadWarning{{Filename: "main/20240516-hello.md", Message: "no date"}}, report.Warnings)
+}
+
+func TestMultiRepository_FetchPosts_SourceFailure(t *testing.T) {
+	main := &StubPostRepository{posts: []blog.Post{{Filename: "20240516-hello.md", Anchor: "hello"}}}
+	notes := &StubPostRepository{err: blog.ErrRateLimited}
+
+	// A failing optional source is reported and skipped
+	repository, err := NewMultiRepository(Source{Name: "main", Repository: main}, Source{Name: "notes", Repository: notes, Optional: true})
+	assert.NoError(t, err)
+
+	posts, report, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Equal(t, []blog.LoadError{{Filename: "notes", Kind: blog.LoadErrorSource, Message: "rate limit exceeded"}}, report.Errors)
+
+	// A failing required source fails the load
+	repository, err = NewMultiRepository(Source{Name: "main", Repository: main}, Source{Name: "notes", Repository: notes})
+	assert.NoError(t, err)
+
+	_, _, err = repository.FetchPosts(context.Background())
+	assert.ErrorIs(t, err, blog.ErrRateLimited)
+	assert.ErrorContains(t, err, "notes")
+}
+
+func TestMultiRepository_CheckHealth(t *testing.T) {
+	main := &StubPostRepository{}
+	notes := &StubPostRepository{err: errors.New("bucket not found")}
+	guest := &StubPostRepository{err: errors.New("unauthorized")}
+	repository, err := NewMultiRepository(
+		Source{Name: "main", Repository: main},
+		Source{Name: "notes", Repository: notes, Optional: true},
+		Source{Name: "guest", Repository: guest},
+	)
+	assert.NoError(t, err)
+
+	checks := repository.CheckHealth(context.Background())
+
+	assert.Equal(t, []blog.HealthCheck{
+		{Name: "main/stub", Healthy: true},
+		{Name: "notes/stub", Healthy: true, Message: "optional source degraded: bucket not found"},
+		{Name: "guest/stub", Message: "unauthorized"},
+	}, checks)
+}
+
+func TestNewMultiRepository_InvalidConfig(t *testing.T) {
+	_, err := NewMultiRepository()
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewMultiRepository(Source{Name: "main"})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewMultiRepository(Source{Repository: &StubPostRepository{}})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewMultiRepository(Source{Name: "main", Repository: &StubPostRepository{}}, Source{Name: "main", Repository: &StubPostRepository{}})
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
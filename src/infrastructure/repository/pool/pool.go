@@ -0,0 +1,121 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+)
+
+// Workers is the number of posts loaded concurrently
+const Workers = 5
+
+// Common errors
+var (
+	ErrContextCancelled = errors.New("context cancelled")
+)
+
+// Loader loads the posts of the files of a source with a fixed number of workers
+type Loader[T any] struct {
+	// Name returns the filename of a file as listed in the load report
+	Name func(file T) string
+	// Load fetches and parses the post of a single file
+	Load func(ctx context.Context, file T) (blog.Post, error)
+	// Kind classifies the error of a post that failed to load
+	Kind func(err error) blog.LoadErrorKind
+}
+
+// result is the outcome of loading a single post
+type result struct {
+	filename string
+	post     blog.Post
+	err      error
+}
+
+// LoadPosts loads the posts of all files concurrently.
+// Posts that fail to load are skipped and listed in the report, while a rate limited post fails the whole load.
+func (l Loader[T]) LoadPosts(ctx context.Context, files []T, report *blog.LoadReport) ([]blog.Post, error) {
+	// Stop the workers once the results are no longer collected
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	posts := make([]blog.Post, 0, len(files))
+	resultChan := make(chan result)
+	workChan := make(chan T, len(files))
+
+	var wg sync.WaitGroup
+	for i := 0; i < Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for file := range workChan {
+				if ctx.Err() != nil {
+					// Context cancelled, stop processing
+					return
+				}
+				post, err := l.Load(ctx, file)
+				select {
+				case resultChan <- result{filename: l.Name(file), post: post, err: err}:
+				case <-ctx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// Queue all files for the workers
+	for _, file := range files {
+		workChan <- file
+	}
+	close(workChan)
+
+	go func() {
+		wg.Wait()
+		close(resultChan)
+	}()
+
+	// Process results, skipping the posts that failed to load
+	for {
+		select {
+		case result, ok := <-resultChan:
+			if !ok {
+				if ctx.Err() != nil {
+					// The workers stopped on cancellation, not after the last file
+					return nil, fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
+				}
+				// All results processed
+				report.Posts = len(posts)
+				return posts, nil
+			}
+			if errors.Is(result.err, blog.ErrRateLimited) {
+				// A partial blog would replace the cached posts, fail the whole load instead
+				return nil, fmt.Errorf("failed to fetch %s: %w", result.filename, result.err)
+			}
+			if result.err != nil {
+				report.AddError(result.filename, l.Kind(result.err), result.err)
+				continue
+			}
+			report.AddWarnings(result.filename, result.post.Warnings)
+			posts = append(posts, result.post)
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %v", ErrContextCancelled, ctx.Err())
+		}
+	}
+}
+
+// ErrorKind classifies load errors by the decoding and parsing sentinel errors of a source,
+// any other error failed to fetch the file
+func ErrorKind(decoding, parsing error) func(err error) blog.LoadErrorKind {
+	return func(err error) blog.LoadErrorKind {
+		switch {
+		case errors.Is(err, decoding):
+			return blog.LoadErrorDecode
+		case errors.Is(err, parsing):
+			return blog.LoadErrorParse
+		default:
+			return blog.LoadErrorFetch
+		}
+	}
+}
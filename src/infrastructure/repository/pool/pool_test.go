@@ -0,0 +1,84 @@
+package pool
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	errDecoding = errors.New("decoding")
+	errParsing  = errors.New("parsing")
+)
+
+// newLoader loads the posts of filenames, failing with the error listed for a filename
+func newLoader(failures map[string]error) Loader[string] {
+	return Loader[string]{
+		Name: func(file string) string { return file },
+		Load: func(_ context.Context, file string) (blog.Post, error) {
+			if err, ok := failures[file]; ok {
+				return blog.Post{}, err
+			}
+			return blog.Post{Filename: file, Warnings: []string{"warning"}}, nil
+		},
+		Kind: ErrorKind(errDecoding, errParsing),
+	}
+}
+
+func TestLoader_LoadPosts(t *testing.T) {
+	loader := newLoader(map[string]error{
+		"binary.md": fmt.Errorf("%w: not UTF-8", errDecoding),
+		"broken.md": fmt.Errorf("%w: bad frontmatter", errParsing),
+		"gone.md":   errors.New("not found"),
+	})
+	report := blog.NewLoadReport(time.Now())
+
+	posts, err := loader.LoadPosts(context.Background(), []string{"a.md", "binary.md", "b.md", "broken.md", "gone.md", "c.md"}, &report)
+	assert.NoError(t, err)
+	assert.Len(t, posts, 3)
+	assert.Equal(t, 3, report.Posts)
+
+	kinds := make(map[string]blog.LoadErrorKind)
+	for _, loadError := range report.Errors {
+		kinds[loadError.Filename] = loadError.Kind
+	}
+	assert.Equal(t, map[string]blog.LoadErrorKind{
+		"binary.md": blog.LoadErrorDecode,
+		"broken.md": blog.LoadErrorParse,
+		"gone.md":   blog.LoadErrorFetch,
+	}, kinds)
+	assert.Len(t, report.Warnings, 3)
+}
+
+func TestLoader_LoadPosts_RateLimited(t *testing.T) {
+	loader := newLoader(map[string]error{"b.md": fmt.Errorf("%w: retry later", blog.ErrRateLimited)})
+	report := blog.NewLoadReport(time.Now())
+
+	// A partial blog would replace the cached posts, so the whole load fails
+	posts, err := loader.LoadPosts(context.Background(), []string{"a.md", "b.md", "c.md"}, &report)
+	assert.ErrorIs(t, err, blog.ErrRateLimited)
+	assert.Nil(t, posts)
+}
+
+func TestLoader_LoadPosts_ContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	report := blog.NewLoadReport(time.Now())
+
+	posts, err := newLoader(nil).LoadPosts(ctx, []string{"a.md", "b.md"}, &report)
+	assert.ErrorIs(t, err, ErrContextCancelled)
+	assert.Nil(t, posts)
+}
+
+func TestLoader_LoadPosts_NoFiles(t *testing.T) {
+	report := blog.NewLoadReport(time.Now())
+
+	posts, err := newLoader(nil).LoadPosts(context.Background(), nil, &report)
+	assert.NoError(t, err)
+	assert.Empty(t, posts)
+}
@@ -0,0 +1,130 @@
+package restapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+)
+
+// Common errors
+var (
+	ErrNotFound        = errors.New("not found")
+	ErrContentDecoding = errors.New("content decoding failure")
+)
+
+// Client calls the JSON REST API of a git forge
+type Client struct {
+	// HTTPClient sends the requests, defaulting to http.DefaultClient
+	HTTPClient *http.Client
+	// BaseURL is the URL the endpoints are relative to
+	BaseURL string
+	// Name is the name of the API in error messages, such as "GitLab API"
+	Name string
+	// Failure is the sentinel error wrapping every failed request
+	Failure error
+	// TokenHeader is the header carrying TokenValue, not set when TokenValue is empty
+	TokenHeader string
+	TokenValue  string
+	// Ref is the branch, tag or commit files are read at. Empty uses the default branch.
+	Ref string
+}
+
+// RefQuery returns the query selecting the configured ref
+func (c *Client) RefQuery() url.Values {
+	query := url.Values{}
+	if c.Ref != "" {
+		query.Set("ref", c.Ref)
+	}
+	return query
+}
+
+// Get calls an endpoint and decodes the JSON response into out.
+// The response is returned for its headers, its body is already closed.
+func (c *Client) Get(ctx context.Context, endpoint string, query url.Values, out any) (*http.Response, error) {
+	requestURL := c.BaseURL + endpoint
+	if len(query) > 0 {
+		requestURL += "?" + query.Encode()
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", c.Failure, err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.TokenValue != "" {
+		req.Header.Set(c.TokenHeader, c.TokenValue)
+	}
+
+	client := c.HTTPClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", c.Failure, err)
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return resp, fmt.Errorf("%w: %w: %s", c.Failure, ErrNotFound, endpoint)
+	case resp.StatusCode == http.StatusTooManyRequests:
+		return resp, fmt.Errorf("%w: %s returned %v", blog.ErrRateLimited, c.Name, resp.StatusCode)
+	case resp.StatusCode != http.StatusOK:
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+		return resp, fmt.Errorf("%w: non 200 response code: %v: %s", c.Failure, resp.StatusCode, body)
+	}
+
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return resp, fmt.Errorf("%w: invalid response: %v", c.Failure, err)
+	}
+	return resp, nil
+}
+
+// ReadFile reads a file by its path relative to the repository root with read,
+// reporting missing files as blog.ErrFileNotFound
+func (c *Client) ReadFile(ctx context.Context, name string, read func(ctx context.Context, filePath string) ([]byte, error)) ([]byte, error) {
+	cleaned, err := blog.CleanPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	content, err := read(ctx, cleaned)
+	if errors.Is(err, ErrNotFound) {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, cleaned)
+	}
+	return content, err
+}
+
+// CheckHealth completes the check by getting the repository at the base URL
+func (c *Client) CheckHealth(ctx context.Context, check blog.HealthCheck) []blog.HealthCheck {
+	var repository struct {
+		ID int `json:"id"`
+	}
+	if _, err := c.Get(ctx, "", nil, &repository); err != nil {
+		check.Message = err.Error()
+		return []blog.HealthCheck{check}
+	}
+
+	check.Healthy = true
+	return []blog.HealthCheck{check}
+}
+
+// DecodeContent decodes the content of a file in the given encoding, base64 or none
+func DecodeContent(encoding, content string) ([]byte, error) {
+	if encoding != "base64" {
+		return []byte(content), nil
+	}
+	decoded, err := base64.StdEncoding.DecodeString(content)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrContentDecoding, err)
+	}
+	return decoded, nil
+}
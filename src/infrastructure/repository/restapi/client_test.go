@@ -0,0 +1,105 @@
+package restapi
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+var errAPIFailure = errors.New("API failure")
+
+// newClient creates a client of a server answering every request with status and body
+func newClient(t *testing.T, status int, body string, requests *[]*http.Request) *Client {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		*requests = append(*requests, req)
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return &Client{
+		HTTPClient:  server.Client(),
+		BaseURL:     server.URL + "/api",
+		Name:        "Test API",
+		Failure:     errAPIFailure,
+		TokenHeader: "PRIVATE-TOKEN",
+		TokenValue:  "secret",
+		Ref:         "v1",
+	}
+}
+
+func TestClient_Get(t *testing.T) {
+	var requests []*http.Request
+	client := newClient(t, http.StatusOK, `{"id": 42}`, &requests)
+
+	var out struct {
+		ID int `json:"id"`
+	}
+	_, err := client.Get(context.Background(), "/files", client.RefQuery(), &out)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 42, out.ID)
+	assert.Len(t, requests, 1)
+	assert.Equal(t, "/api/files", requests[0].URL.Path)
+	assert.Equal(t, "v1", requests[0].URL.Query().Get("ref"))
+	assert.Equal(t, "secret", requests[0].Header.Get("PRIVATE-TOKEN"))
+}
+
+func TestClient_Get_Errors(t *testing.T) {
+	tests := []struct {
+		name   string
+		status int
+		body   string
+		err    error
+	}{
+		{name: "not found", status: http.StatusNotFound, err: ErrNotFound},
+		{name: "rate limited", status: http.StatusTooManyRequests, err: blog.ErrRateLimited},
+		{name: "server error", status: http.StatusBadGateway, err: errAPIFailure},
+		{name: "invalid response", status: http.StatusOK, body: "<html>", err: errAPIFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []*http.Request
+			client := newClient(t, tt.status, tt.body, &requests)
+
+			var out map[string]any
+			_, err := client.Get(context.Background(), "/files", nil, &out)
+
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestClient_ReadFile_NotFound(t *testing.T) {
+	var requests []*http.Request
+	client := newClient(t, http.StatusNotFound, "", &requests)
+
+	_, err := client.ReadFile(context.Background(), "./examples/../missing.go", func(ctx context.Context, filePath string) ([]byte, error) {
+		var out map[string]any
+		_, err := client.Get(ctx, "/files/"+filePath, nil, &out)
+		return nil, err
+	})
+
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+	assert.Equal(t, "/api/files/missing.go", requests[0].URL.Path)
+}
+
+func TestDecodeContent(t *testing.T) {
+	content, err := DecodeContent("base64", "aGVsbG8=")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	content, err = DecodeContent("", "hello")
+	assert.NoError(t, err)
+	assert.Equal(t, "hello", string(content))
+
+	_, err = DecodeContent("base64", "not base64!")
+	assert.ErrorIs(t, err, ErrContentDecoding)
+}
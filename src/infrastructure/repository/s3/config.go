@@ -0,0 +1,18 @@
+package s3
+
+// Config holds the configuration for the S3 repository
+type Config struct {
+	// Bucket is the name of the bucket holding the posts
+	Bucket string
+
+	// Prefix is the key prefix of the posts, such as "posts/". Only objects directly below it are posts.
+	Prefix string
+}
+
+// NewConfig creates a new Config instance with the given parameters
+func NewConfig(bucket, prefix string) *Config {
+	return &Config{
+		Bucket: bucket,
+		Prefix: prefix,
+	}
+}
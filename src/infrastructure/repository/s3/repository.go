@@ -0,0 +1,295 @@
+package s3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+	"unicode/utf8"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/repository/pool"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/aws/smithy-go"
+)
+
+// Common errors
+var (
+	ErrInvalidConfig    = errors.New("invalid repository configuration")
+	ErrS3Failure        = errors.New("S3 failure")
+	ErrContentDecoding  = errors.New("content decoding failure")
+	ErrMarkdownParsing  = errors.New("markdown parsing failure")
+	ErrContextCancelled = pool.ErrContextCancelled
+)
+
+// Client is the part of the S3 API used to read posts
+type Client interface {
+	s3.ListObjectsV2APIClient
+	GetObject(ctx context.Context, params *s3.GetObjectInput, optFns ...func(*s3.Options)) (*s3.GetObjectOutput, error)
+	HeadBucket(ctx context.Context, params *s3.HeadBucketInput, optFns ...func(*s3.Options)) (*s3.HeadBucketOutput, error)
+}
+
+// S3Repository implements the PostRepository interface using an S3 or S3-compatible bucket
+type S3Repository struct {
+	client         Client
+	markdownParser blog.MarkdownParser
+	config         *Config
+
+	// posts caches the parsed posts by key, reused while the ETag of their object is unchanged
+	mu    sync.Mutex
+	posts map[string]cachedPost
+}
+
+// cachedPost is a parsed post with the ETag of the object it was parsed from
+type cachedPost struct {
+	etag string
+	post blog.Post
+}
+
+// recordingFiles reads the files referenced by a single post, recording whether it read any
+type recordingFiles struct {
+	files blog.FileReader
+	read  atomic.Bool
+}
+
+// ReadFile reads a file through the repository and records the read
+func (f *recordingFiles) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	f.read.Store(true)
+	return f.files.ReadFile(ctx, name)
+}
+
+// NewS3Repository creates a new S3Repository instance
+func NewS3Repository(client Client, markdownParser blog.MarkdownParser, config *Config) (*S3Repository, error) {
+	if client == nil {
+		return nil, fmt.Errorf("%w: S3 client is required", ErrInvalidConfig)
+	}
+
+	if markdownParser == nil {
+		return nil, fmt.Errorf("%w: markdown parser is required", ErrInvalidConfig)
+	}
+
+	if config == nil || config.Bucket == "" {
+		return nil, fmt.Errorf("%w: bucket is required", ErrInvalidConfig)
+	}
+
+	// Only objects directly below the prefix are listed, so it must end with the delimiter
+	normalized := *config
+	if normalized.Prefix != "" && !strings.HasSuffix(normalized.Prefix, "/") {
+		normalized.Prefix += "/"
+	}
+
+	return &S3Repository{
+		client:         client,
+		markdownParser: markdownParser,
+		config:         &normalized,
+		posts:          make(map[string]cachedPost),
+	}, nil
+}
+
+// FetchPosts fetches all blog posts from the bucket, reparsing only the objects whose ETag changed.
+// Posts that fail to load are skipped and listed in the report.
+func (r *S3Repository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	report := blog.NewLoadReport(time.Now())
+
+	// Add timeout to context if not already set
+	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
+	defer cancel()
+
+	objects, err := r.listObjects(ctx)
+	if err != nil {
+		return nil, report, fmt.Errorf("failed to list objects: %w", err)
+	}
+
+	// Filter markdown files
+	var mdFiles []types.Object
+	for _, object := range objects {
+		if strings.HasSuffix(aws.ToString(object.Key), ".md") {
+			mdFiles = append(mdFiles, object)
+		}
+	}
+
+	if len(mdFiles) == 0 {
+		r.retain(nil)
+		return []blog.Post{}, report, nil
+	}
+
+	posts, err := pool.Loader[types.Object]{
+		Name: r.filename,
+		Load: r.fetchPost,
+		Kind: pool.ErrorKind(ErrContentDecoding, ErrMarkdownParsing),
+	}.LoadPosts(ctx, mdFiles, &report)
+	if err != nil {
+		return nil, report, err
+	}
+
+	r.retain(mdFiles)
+	return posts, report, nil
+}
+
+// listObjects lists the objects directly below the prefix, following the pages of the listing
+func (r *S3Repository) listObjects(ctx context.Context) ([]types.Object, error) {
+	paginator := s3.NewListObjectsV2Paginator(r.client, &s3.ListObjectsV2Input{
+		Bucket:    aws.String(r.config.Bucket),
+		Prefix:    aws.String(r.config.Prefix),
+		Delimiter: aws.String("/"),
+	})
+
+	var objects []types.Object
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, s3Error(err)
+		}
+		objects = append(objects, page.Contents...)
+	}
+	return objects, nil
+}
+
+// filename returns the key of the object relative to the prefix
+func (r *S3Repository) filename(object types.Object) string {
+	return strings.TrimPrefix(aws.ToString(object.Key), r.config.Prefix)
+}
+
+// fetchPost returns the cached post while the ETag of the object is unchanged, otherwise it fetches and parses it.
+// Posts that include other objects are not cached, as the ETag of the post does not change with them.
+// Posts without an updated date in their frontmatter are dated by the LastModified of their object.
+func (r *S3Repository) fetchPost(ctx context.Context, object types.Object) (blog.Post, error) {
+	key := aws.ToString(object.Key)
+	etag := aws.ToString(object.ETag)
+
+	r.mu.Lock()
+	cached, ok := r.posts[key]
+	r.mu.Unlock()
+	if ok && etag != "" && cached.etag == etag {
+		return cached.post, nil
+	}
+
+	content, err := r.getObject(ctx, key)
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("failed to get post content: %w", err)
+	}
+	if !utf8.Valid(content) {
+		return blog.Post{}, fmt.Errorf("%w: %s is not valid UTF-8", ErrContentDecoding, key)
+	}
+
+	filename := r.filename(object)
+	files := &recordingFiles{files: r}
+	parsed, err := r.markdownParser.ParseDocument(ctx, blog.Document{
+		Filename: filename,
+		Content:  string(content),
+		Files:    files,
+	})
+	if err != nil {
+		return blog.Post{}, fmt.Errorf("%w: %v", ErrMarkdownParsing, err)
+	}
+
+	post := blog.NewPost(filename, string(content), parsed)
+	if post.UpdatedAt.IsZero() && object.LastModified != nil {
+		post.UpdatedAt = object.LastModified.UTC()
+	}
+
+	r.mu.Lock()
+	if files.read.Load() {
+		delete(r.posts, key)
+	} else {
+		r.posts[key] = cachedPost{etag: etag, post: post}
+	}
+	r.mu.Unlock()
+
+	return post, nil
+}
+
+// retain drops the cached posts of objects that no longer exist
+func (r *S3Repository) retain(objects []types.Object) {
+	keys := make(map[string]bool, len(objects))
+	for _, object := range objects {
+		keys[aws.ToString(object.Key)] = true
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key := range r.posts {
+		if !keys[key] {
+			delete(r.posts, key)
+		}
+	}
+}
+
+// getObject gets the content of an object
+func (r *S3Repository) getObject(ctx context.Context, key string) ([]byte, error) {
+	output, err := r.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.config.Bucket),
+		Key:    aws.String(key),
+	})
+
+	var noSuchKey *types.NoSuchKey
+	if errors.As(err, &noSuchKey) {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, key)
+	}
+	if err != nil {
+		return nil, s3Error(err)
+	}
+	defer output.Body.Close()
+
+	content, err := io.ReadAll(output.Body)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrS3Failure, err)
+	}
+	return content, nil
+}
+
+// s3Error wraps an error of the S3 API, reporting throttled requests as rate limited
+func s3Error(err error) error {
+	var apiErr smithy.APIError
+	if errors.As(err, &apiErr) && apiErr.ErrorCode() == "SlowDown" {
+		return fmt.Errorf("%w: %v", blog.ErrRateLimited, err)
+	}
+	return fmt.Errorf("%w: %v", ErrS3Failure, err)
+}
+
+// ReadFile reads a file by its path relative to the parent of the prefix, the root the posts are published to
+func (r *S3Repository) ReadFile(ctx context.Context, name string) ([]byte, error) {
+	cleaned, err := blog.CleanPath(name)
+	if err != nil {
+		return nil, err
+	}
+
+	root := path.Dir(strings.TrimSuffix(r.config.Prefix, "/"))
+	if root == "." {
+		root = ""
+	} else {
+		root += "/"
+	}
+
+	content, err := r.getObject(ctx, root+cleaned)
+	if errors.Is(err, blog.ErrFileNotFound) {
+		return nil, fmt.Errorf("%w: %s", blog.ErrFileNotFound, cleaned)
+	}
+	return content, err
+}
+
+// CheckHealth checks that the bucket is reachable
+func (r *S3Repository) CheckHealth(ctx context.Context) []blog.HealthCheck {
+	check := blog.HealthCheck{
+		Name: "s3",
+		Details: map[string]any{
+			"bucket": r.config.Bucket,
+			"prefix": r.config.Prefix,
+		},
+	}
+
+	if _, err := r.client.HeadBucket(ctx, &s3.HeadBucketInput{Bucket: aws.String(r.config.Bucket)}); err != nil {
+		check.Message = fmt.Sprintf("%v: %v", ErrS3Failure, err)
+		return []blog.HealthCheck{check}
+	}
+
+	check.Healthy = true
+	return []blog.HealthCheck{check}
+}
@@ -0,0 +1,296 @@
+package s3
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"buyallmemes.com/blog-api/src/infrastructure/markdown"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeObject is an object stored by the fake S3 API
+type fakeObject struct {
+	content      string
+	etag         string
+	lastModified time.Time
+}
+
+// fakeS3 is a local fake of the S3 API serving the path-style ListObjectsV2, GetObject and HeadBucket
+// requests of a single in-memory bucket
+type fakeS3 struct {
+	bucket  string
+	objects map[string]fakeObject
+	gets    atomic.Int32
+	// slowDown throttles the requests for the key
+	slowDown string
+}
+
+// listBucketResult is the ListObjectsV2 response
+type listBucketResult struct {
+	XMLName               xml.Name       `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListBucketResult"`
+	Name                  string         `xml:"Name"`
+	Prefix                string         `xml:"Prefix"`
+	KeyCount              int            `xml:"KeyCount"`
+	IsTruncated           bool           `xml:"IsTruncated"`
+	NextContinuationToken string         `xml:"NextContinuationToken,omitempty"`
+	Contents              []listedObject `xml:"Contents"`
+	CommonPrefixes        []commonPrefix `xml:"CommonPrefixes"`
+}
+
+type listedObject struct {
+	Key          string `xml:"Key"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int    `xml:"Size"`
+}
+
+type commonPrefix struct {
+	Prefix string `xml:"Prefix"`
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	bucket, key, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/"), "/")
+	if bucket != f.bucket {
+		f.error(w, http.StatusNotFound, "NoSuchBucket")
+		return
+	}
+
+	switch {
+	case r.Method == http.MethodHead && key == "":
+		w.WriteHeader(http.StatusOK)
+	case r.Method == http.MethodGet && key == "" && r.URL.Query().Get("list-type") == "2":
+		f.list(w, r)
+	case r.Method == http.MethodGet:
+		if key == f.slowDown {
+			f.error(w, http.StatusServiceUnavailable, "SlowDown")
+			return
+		}
+		object, ok := f.objects[key]
+		if !ok {
+			f.error(w, http.StatusNotFound, "NoSuchKey")
+			return
+		}
+		f.gets.Add(1)
+		w.Header().Set("ETag", object.etag)
+		w.Header().Set("Last-Modified", object.lastModified.Format(http.TimeFormat))
+		_, _ = w.Write([]byte(object.content))
+	default:
+		f.error(w, http.StatusMethodNotAllowed, "MethodNotAllowed")
+	}
+}
+
+// list lists the objects directly below the prefix, two per page
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	prefix := r.URL.Query().Get("prefix")
+	result := listBucketResult{Name: f.bucket, Prefix: prefix}
+
+	var keys []string
+	prefixes := make(map[string]bool)
+	for key := range f.objects {
+		rest, ok := strings.CutPrefix(key, prefix)
+		if !ok {
+			continue
+		}
+		if dir, _, isDir := strings.Cut(rest, "/"); isDir {
+			prefixes[prefix+dir+"/"] = true
+			continue
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for p := range prefixes {
+		result.CommonPrefixes = append(result.CommonPrefixes, commonPrefix{Prefix: p})
+	}
+
+	start := 0
+	if token := r.URL.Query().Get("continuation-token"); token != "" {
+		start = sort.SearchStrings(keys, token)
+	}
+	end := min(start+2, len(keys))
+	if end < len(keys) {
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[end]
+	}
+
+	for _, key := range keys[start:end] {
+		object := f.objects[key]
+		result.Contents = append(result.Contents, listedObject{
+			Key:          key,
+			LastModified: object.lastModified.Format("2006-01-02T15:04:05.000Z"),
+			ETag:         object.etag,
+			Size:         len(object.content),
+		})
+	}
+	result.KeyCount = len(result.Contents)
+
+	w.Header().Set("Content-Type", "application/xml")
+	_ = xml.NewEncoder(w).Encode(result)
+}
+
+func (f *fakeS3) error(w http.ResponseWriter, status int, code string) {
+	w.Header().Set("Content-Type", "application/xml")
+	w.WriteHeader(status)
+	_, _ = w.Write([]byte("<Error><Code>" + code + "</Code><Message>" + code + "</Message></Error>"))
+}
+
+func newFakeS3() *fakeS3 {
+	modified := time.Date(2024, 5, 20, 10, 0, 0, 0, time.UTC)
+	return &fakeS3{
+		bucket: "blog",
+		objects: map[string]fakeObject{
+			"posts/20240516-good.md":    {content: "---\ntitle: Good\ndate: 16.05.2024\n---\nHello!\n", etag: `"good"`, lastModified: modified},
+			"posts/20240517-broken.md":  {content: "---\ntitle: Broken\ndate: 17.05.2024\n---\n{{< vimeo 1 >}}\n", etag: `"broken"`, lastModified: modified},
+			"posts/20240518-updated.md": {content: "---\ntitle: Updated\ndate: 18.05.2024\nupdated: 19.05.2024\n---\nHello!\n", etag: `"updated"`, lastModified: modified},
+			"posts/20240519-binary.md":  {content: "\xff\xfe", etag: `"binary"`, lastModified: modified},
+			"posts/notes.txt":           {content: "not a post", etag: `"notes"`, lastModified: modified},
+			"posts/drafts/draft.md":     {content: "---\ntitle: Draft\ndate: 19.05.2024\n---\nHello!\n", etag: `"draft"`, lastModified: modified},
+			"examples/main.go":          {content: "package main\n", etag: `"main"`, lastModified: modified},
+		},
+	}
+}
+
+func newTestRepository(t *testing.T, fake *fakeS3, prefix string) *S3Repository {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+
+	client := s3.New(s3.Options{
+		Region:           "us-east-1",
+		BaseEndpoint:     aws.String(server.URL),
+		UsePathStyle:     true,
+		Credentials:      credentials.NewStaticCredentialsProvider("key", "secret", ""),
+		RetryMaxAttempts: 1,
+	})
+
+	repository, err := NewS3Repository(client, markdown.NewGoldmarkParser(), NewConfig(fake.bucket, prefix))
+	assert.NoError(t, err)
+	return repository
+}
+
+func TestS3Repository_FetchPosts(t *testing.T) {
+	repository := newTestRepository(t, newFakeS3(), "posts")
+
+	posts, report, err := repository.FetchPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, posts, 2)
+	assert.Equal(t, 2, report.Posts)
+
+	sort.Slice(posts, func(i, j int) bool { return posts[i].Filename < posts[j].Filename })
+	assert.Equal(t, "20240516-good.md", posts[0].Filename)
+	assert.Equal(t, time.Date(2024, 5, 20, 10, 0, 0, 0, time.UTC), posts[0].UpdatedAt)
+	assert.Equal(t, time.Date(2024, 5, 19, 0, 0, 0, 0, time.UTC), posts[1].UpdatedAt)
+
+	sort.Slice(report.Errors, func(i, j int) bool { return report.Errors[i].Filename < report.Errors[j].Filename })
+	assert.Len(t, report.Errors, 2)
+	assert.Equal(t, "20240517-broken.md", report.Errors[0].Filename)
+	assert.Equal(t, blog.LoadErrorParse, report.Errors[0].Kind)
+	assert.Equal(t, "20240519-binary.md", report.Errors[1].Filename)
+	assert.Equal(t, blog.LoadErrorDecode, report.Errors[1].Kind)
+}
+
+func TestS3Repository_FetchPosts_ReusesUnchangedPosts(t *testing.T) {
+	fake := newFakeS3()
+	repository := newTestRepository(t, fake, "posts/")
+
+	_, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(4), fake.gets.Load())
+
+	// Only the changed object is fetched again, the broken one is retried
+	changed := fake.objects["posts/20240516-good.md"]
+	changed.content = "---\ntitle: Better\ndate: 16.05.2024\n---\nHello!\n"
+	changed.etag = `"better"`
+	fake.objects["posts/20240516-good.md"] = changed
+
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, int32(7), fake.gets.Load())
+
+	sort.Slice(posts, func(i, j int) bool { return posts[i].Filename < posts[j].Filename })
+	assert.Equal(t, "Better", posts[0].Title)
+}
+
+func TestS3Repository_FetchPosts_RefreshesIncludes(t *testing.T) {
+	fake := newFakeS3()
+	fake.objects = map[string]fakeObject{
+		"posts/20240516-include.md": {content: "---\ntitle: Include\ndate: 16.05.2024\n---\n{{< include examples/main.go >}}\n", etag: `"include"`},
+		"examples/main.go":          {content: "package main\n", etag: `"main"`},
+	}
+	repository := newTestRepository(t, fake, "posts/")
+
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Contains(t, posts[0].Content, ">main<")
+
+	// The post is unchanged, but the object it includes is not
+	fake.objects["examples/main.go"] = fakeObject{content: "package example\n", etag: `"example"`}
+
+	posts, _, err = repository.FetchPosts(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, posts, 1)
+	assert.Contains(t, posts[0].Content, ">example<")
+}
+
+func TestS3Repository_FetchPosts_RateLimited(t *testing.T) {
+	fake := newFakeS3()
+	fake.slowDown = "posts/20240516-good.md"
+	repository := newTestRepository(t, fake, "posts")
+
+	// A throttled post fails the whole load instead of being skipped
+	posts, _, err := repository.FetchPosts(context.Background())
+	assert.ErrorIs(t, err, blog.ErrRateLimited)
+	assert.Nil(t, posts)
+}
+
+func TestS3Repository_ReadFile(t *testing.T) {
+	repository := newTestRepository(t, newFakeS3(), "posts/")
+
+	content, err := repository.ReadFile(context.Background(), "examples/main.go")
+	assert.NoError(t, err)
+	assert.Equal(t, "package main\n", string(content))
+
+	_, err = repository.ReadFile(context.Background(), "examples/missing.go")
+	assert.ErrorIs(t, err, blog.ErrFileNotFound)
+
+	_, err = repository.ReadFile(context.Background(), "../outside.go")
+	assert.ErrorIs(t, err, blog.ErrInvalidPath)
+}
+
+func TestS3Repository_CheckHealth(t *testing.T) {
+	fake := newFakeS3()
+	repository := newTestRepository(t, fake, "posts/")
+
+	checks := repository.CheckHealth(context.Background())
+	assert.Len(t, checks, 1)
+	assert.True(t, checks[0].Healthy)
+
+	fake.bucket = "other"
+	checks = repository.CheckHealth(context.Background())
+	assert.False(t, checks[0].Healthy)
+}
+
+func TestNewS3Repository_InvalidConfig(t *testing.T) {
+	client := s3.New(s3.Options{Region: "us-east-1"})
+	parser := markdown.NewGoldmarkParser()
+
+	_, err := NewS3Repository(nil, parser, NewConfig("blog", "posts/"))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewS3Repository(client, nil, NewConfig("blog", "posts/"))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+
+	_, err = NewS3Repository(client, parser, NewConfig("", "posts/"))
+	assert.ErrorIs(t, err, ErrInvalidConfig)
+}
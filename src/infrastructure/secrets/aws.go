@@ -0,0 +1,111 @@
+package secrets
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/secretsmanager"
+	secretsmanagertypes "github.com/aws/aws-sdk-go-v2/service/secretsmanager/types"
+	"github.com/aws/aws-sdk-go-v2/service/ssm"
+	ssmtypes "github.com/aws/aws-sdk-go-v2/service/ssm/types"
+)
+
+// NewDefaultResolver creates a Resolver for SSM parameters, Secrets Manager secrets, files and environment variables
+func NewDefaultResolver(cfg aws.Config) *Resolver {
+	return NewResolver(map[string]Provider{
+		SchemeSSM:            NewSSMProvider(ssm.NewFromConfig(cfg)),
+		SchemeSecretsManager: NewSecretsManagerProvider(secretsmanager.NewFromConfig(cfg)),
+		SchemeFile:           NewFileProvider(),
+		SchemeEnv:            NewEnvProvider(),
+	})
+}
+
+// SSMClient is the part of the SSM API used to read parameters
+type SSMClient interface {
+	GetParameter(ctx context.Context, params *ssm.GetParameterInput, optFns ...func(*ssm.Options)) (*ssm.GetParameterOutput, error)
+}
+
+// SSMProvider reads secrets from SSM Parameter Store, decrypting SecureString parameters
+type SSMProvider struct {
+	client SSMClient
+}
+
+// NewSSMProvider creates a new SSMProvider
+func NewSSMProvider(client SSMClient) *SSMProvider {
+	return &SSMProvider{client: client}
+}
+
+// GetSecret returns the value of the parameter
+func (p *SSMProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	output, err := p.client.GetParameter(ctx, &ssm.GetParameterInput{
+		Name:           aws.String(name),
+		WithDecryption: aws.Bool(true),
+	})
+
+	var notFound *ssmtypes.ParameterNotFound
+	if errors.As(err, &notFound) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSecretLoading, err)
+	}
+	if output.Parameter == nil {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+
+	return aws.ToString(output.Parameter.Value), nil
+}
+
+// SecretsManagerClient is the part of the Secrets Manager API used to read secrets
+type SecretsManagerClient interface {
+	GetSecretValue(ctx context.Context, params *secretsmanager.GetSecretValueInput, optFns ...func(*secretsmanager.Options)) (*secretsmanager.GetSecretValueOutput, error)
+}
+
+// SecretsManagerProvider reads secrets from AWS Secrets Manager.
+// A name of the form "secret#key" selects a key of a JSON secret.
+type SecretsManagerProvider struct {
+	client SecretsManagerClient
+}
+
+// NewSecretsManagerProvider creates a new SecretsManagerProvider
+func NewSecretsManagerProvider(client SecretsManagerClient) *SecretsManagerProvider {
+	return &SecretsManagerProvider{client: client}
+}
+
+// GetSecret returns the value of the secret, or of the key of a JSON secret
+func (p *SecretsManagerProvider) GetSecret(ctx context.Context, name string) (string, error) {
+	secretID, key, hasKey := strings.Cut(name, "#")
+
+	output, err := p.client.GetSecretValue(ctx, &secretsmanager.GetSecretValueInput{
+		SecretId: aws.String(secretID),
+	})
+
+	var notFound *secretsmanagertypes.ResourceNotFoundException
+	if errors.As(err, &notFound) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, secretID)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSecretLoading, err)
+	}
+	if output.SecretString == nil {
+		return "", fmt.Errorf("%w: %s is not a string secret", ErrSecretLoading, secretID)
+	}
+
+	if !hasKey {
+		return *output.SecretString, nil
+	}
+
+	var values map[string]any
+	if err := json.Unmarshal([]byte(*output.SecretString), &values); err != nil {
+		return "", fmt.Errorf("%w: %s is not a JSON secret: %v", ErrSecretLoading, secretID, err)
+	}
+	value, ok := values[key].(string)
+	if !ok {
+		return "", fmt.Errorf("%w: %s has no string key %q", ErrSecretNotFound, secretID, key)
+	}
+	return value, nil
+}
@@ -0,0 +1,48 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// EnvProvider reads secrets from environment variables, for local runs
+type EnvProvider struct {
+	lookup func(key string) (string, bool)
+}
+
+// NewEnvProvider creates a new EnvProvider reading the process environment
+func NewEnvProvider() *EnvProvider {
+	return &EnvProvider{lookup: os.LookupEnv}
+}
+
+// GetSecret returns the value of the environment variable
+func (p *EnvProvider) GetSecret(_ context.Context, name string) (string, error) {
+	value, ok := p.lookup(name)
+	if !ok {
+		return "", fmt.Errorf("%w: environment variable %s is not set", ErrSecretNotFound, name)
+	}
+	return value, nil
+}
+
+// FileProvider reads secrets from files, such as mounted secrets or local key files
+type FileProvider struct{}
+
+// NewFileProvider creates a new FileProvider
+func NewFileProvider() *FileProvider {
+	return &FileProvider{}
+}
+
+// GetSecret returns the content of the file without its trailing newline
+func (p *FileProvider) GetSecret(_ context.Context, name string) (string, error) {
+	content, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return "", fmt.Errorf("%w: %s", ErrSecretNotFound, name)
+	}
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", ErrSecretLoading, err)
+	}
+	return strings.TrimRight(string(content), "\r\n"), nil
+}
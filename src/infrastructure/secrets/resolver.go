@@ -0,0 +1,71 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Common errors
+var (
+	ErrSecretNotFound = errors.New("secret not found")
+	ErrSecretLoading  = errors.New("secret loading failed")
+)
+
+// Secret reference schemes
+const (
+	SchemeSSM            = "ssm"
+	SchemeSecretsManager = "secretsmanager"
+	SchemeFile           = "file"
+	SchemeEnv            = "env"
+)
+
+// Provider loads secrets from a secret store
+type Provider interface {
+	// GetSecret returns the secret stored under the name
+	GetSecret(ctx context.Context, name string) (string, error)
+}
+
+// Resolver resolves configuration values referencing a secret store, such as "ssm:/blog/github-token",
+// through the provider registered for the scheme. Resolved secrets are cached for the lifetime of the resolver.
+type Resolver struct {
+	providers map[string]Provider
+
+	mu    sync.Mutex
+	cache map[string]string
+}
+
+// NewResolver creates a new Resolver with the providers by scheme
+func NewResolver(providers map[string]Provider) *Resolver {
+	return &Resolver{
+		providers: providers,
+		cache:     make(map[string]string),
+	}
+}
+
+// Resolve returns the secret referenced by the value, or the value itself when it doesn't start
+// with the scheme of a registered provider
+func (r *Resolver) Resolve(ctx context.Context, value string) (string, error) {
+	scheme, name, found := strings.Cut(value, ":")
+	provider, ok := r.providers[scheme]
+	if !found || !ok {
+		return value, nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if secret, ok := r.cache[value]; ok {
+		return secret, nil
+	}
+
+	secret, err := provider.GetSecret(ctx, name)
+	if err != nil {
+		return "", fmt.Errorf("error resolving %s secret %q: %w", scheme, name, err)
+	}
+
+	r.cache[value] = secret
+	return secret, nil
+}
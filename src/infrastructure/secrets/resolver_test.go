@@ -0,0 +1,98 @@
+package secrets
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// FakeProvider is an in-memory implementation of the Provider interface
+type FakeProvider struct {
+	secrets map[string]string
+	calls   int
+}
+
+func (f *FakeProvider) GetSecret(_ context.Context, name string) (string, error) {
+	f.calls++
+	secret, ok := f.secrets[name]
+	if !ok {
+		return "", ErrSecretNotFound
+	}
+	return secret, nil
+}
+
+func TestResolver_Resolve(t *testing.T) {
+	provider := &FakeProvider{secrets: map[string]string{"/blog/github-token": "ghp_secret"}}
+	resolver := NewResolver(map[string]Provider{SchemeSSM: provider})
+
+	tests := []struct {
+		name     string
+		value    string
+		expected string
+	}{
+		{name: "secret reference", value: "ssm:/blog/github-token", expected: "ghp_secret"},
+		{name: "plain value", value: "ghp_plain", expected: "ghp_plain"},
+		{name: "empty value", value: "", expected: ""},
+		{name: "unregistered scheme", value: "https://buyallmemes.com", expected: "https://buyallmemes.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret, err := resolver.Resolve(context.Background(), tt.value)
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, secret)
+		})
+	}
+}
+
+func TestResolver_Resolve_CachesSecrets(t *testing.T) {
+	provider := &FakeProvider{secrets: map[string]string{"/blog/github-token": "ghp_secret"}}
+	resolver := NewResolver(map[string]Provider{SchemeSSM: provider})
+
+	for range 3 {
+		secret, err := resolver.Resolve(context.Background(), "ssm:/blog/github-token")
+		assert.NoError(t, err)
+		assert.Equal(t, "ghp_secret", secret)
+	}
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestResolver_Resolve_MissingSecret(t *testing.T) {
+	resolver := NewResolver(map[string]Provider{SchemeSSM: &FakeProvider{}})
+
+	_, err := resolver.Resolve(context.Background(), "ssm:/blog/missing")
+
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+	assert.Contains(t, err.Error(), `ssm secret "/blog/missing"`)
+}
+
+func TestEnvProvider_GetSecret(t *testing.T) {
+	provider := &EnvProvider{lookup: func(key string) (string, bool) {
+		value, ok := map[string]string{"GITHUB_TOKEN": "ghp_env"}[key]
+		return value, ok
+	}}
+
+	secret, err := provider.GetSecret(context.Background(), "GITHUB_TOKEN")
+	assert.NoError(t, err)
+	assert.Equal(t, "ghp_env", secret)
+
+	_, err = provider.GetSecret(context.Background(), "MISSING")
+	assert.ErrorIs(t, err, ErrSecretNotFound)
+}
+
+func TestFileProvider_GetSecret(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "token")
+	assert.NoError(t, os.WriteFile(path, []byte("ghp_file\n"), 0o600))
+
+	secret, err := NewFileProvider().GetSecret(context.Background(), path)
+	assert.NoError(t, err)
+	assert.Equal(t, "ghp_file", secret)
+
+	_, err = NewFileProvider().GetSecret(context.Background(), filepath.Join(t.TempDir(), "missing"))
+	assert.True(t, errors.Is(err, ErrSecretNotFound))
+}
@@ -0,0 +1,53 @@
+package seo
+
+import (
+	"strings"
+)
+
+// RobotsConfig holds the configuration for robots.txt
+type RobotsConfig struct {
+	// UserAgent is the crawler the rules apply to
+	UserAgent string
+
+	// Allow lists the paths crawlers may visit
+	Allow []string
+
+	// Disallow lists the paths crawlers must not visit
+	Disallow []string
+
+	// SitemapURL is the absolute URL of the sitemap
+	SitemapURL string
+}
+
+// NewRobotsConfig creates a new RobotsConfig applying to all crawlers
+func NewRobotsConfig(sitemapURL string, allow, disallow []string) *RobotsConfig {
+	return &RobotsConfig{
+		UserAgent:  "*",
+		Allow:      allow,
+		Disallow:   disallow,
+		SitemapURL: sitemapURL,
+	}
+}
+
+// GenerateRobots renders robots.txt for the given configuration
+func GenerateRobots(config *RobotsConfig) string {
+	var b strings.Builder
+
+	b.WriteString("User-agent: " + config.UserAgent + "\n")
+	for _, path := range config.Allow {
+		b.WriteString("Allow: " + path + "\n")
+	}
+	for _, path := range config.Disallow {
+		b.WriteString("Disallow: " + path + "\n")
+	}
+	if len(config.Allow) == 0 && len(config.Disallow) == 0 {
+		// An empty Disallow rule allows crawling the whole site
+		b.WriteString("Disallow:\n")
+	}
+
+	if config.SitemapURL != "" {
+		b.WriteString("\nSitemap: " + config.SitemapURL + "\n")
+	}
+
+	return b.String()
+}
@@ -0,0 +1,23 @@
+package seo
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGenerateRobots(t *testing.T) {
+	config := NewRobotsConfig("https://buyallmemes.com/sitemap.xml", []string{"/"}, []string{"/admin"})
+
+	robots := GenerateRobots(config)
+
+	assert.Equal(t, "User-agent: *\nAllow: /\nDisallow: /admin\n\nSitemap: https://buyallmemes.com/sitemap.xml\n", robots)
+}
+
+func TestGenerateRobots_AllowsEverythingByDefault(t *testing.T) {
+	config := NewRobotsConfig("", nil, nil)
+
+	robots := GenerateRobots(config)
+
+	assert.Equal(t, "User-agent: *\nDisallow:\n", robots)
+}
@@ -0,0 +1,145 @@
+package seo
+
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+)
+
+// MaxURLsPerSitemap is the maximum number of URLs allowed in a single sitemap file
+const MaxURLsPerSitemap = 50000
+
+// sitemapNamespace is the XML namespace of the sitemap protocol
+const sitemapNamespace = "http://www.sitemaps.org/schemas/sitemap/0.9"
+
+// Common errors
+var (
+	ErrInvalidSitemapConfig = errors.New("invalid sitemap configuration")
+	ErrSitemapPageNotFound  = errors.New("sitemap page not found")
+)
+
+// urlSet is the root element of a sitemap file
+type urlSet struct {
+	XMLName xml.Name `xml:"urlset"`
+	Xmlns   string   `xml:"xmlns,attr"`
+	URLs    []URL    `xml:"url"`
+}
+
+// sitemapIndex is the root element of a sitemap index file
+type sitemapIndex struct {
+	XMLName  xml.Name        `xml:"sitemapindex"`
+	Xmlns    string          `xml:"xmlns,attr"`
+	Sitemaps []sitemapRecord `xml:"sitemap"`
+}
+
+// sitemapRecord references a single sitemap from a sitemap index
+type sitemapRecord struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// URL is a single entry of a sitemap
+type URL struct {
+	Loc     string `xml:"loc"`
+	LastMod string `xml:"lastmod,omitempty"`
+}
+
+// SitemapGenerator builds sitemaps from blog posts
+type SitemapGenerator struct {
+	siteURL  string
+	postPath string
+	maxURLs  int
+}
+
+// NewSitemapGenerator creates a new SitemapGenerator for posts served under siteURL + postPath + anchor
+func NewSitemapGenerator(siteURL, postPath string) (*SitemapGenerator, error) {
+	parsed, err := url.Parse(siteURL)
+	if err != nil || parsed.Scheme == "" || parsed.Host == "" {
+		return nil, fmt.Errorf("%w: site URL must be absolute: %q", ErrInvalidSitemapConfig, siteURL)
+	}
+
+	return &SitemapGenerator{
+		siteURL:  strings.TrimSuffix(siteURL, "/"),
+		postPath: postPath,
+		maxURLs:  MaxURLsPerSitemap,
+	}, nil
+}
+
+// URLs returns the sitemap entries for all indexable posts
+func (g *SitemapGenerator) URLs(posts []blog.Post) []URL {
+	urls := make([]URL, 0, len(posts))
+	for _, post := range posts {
+		if !post.IsIndexable() {
+			continue
+		}
+
+		entry := URL{Loc: g.siteURL + g.postPath + url.PathEscape(post.Anchor)}
+		if lastModified := post.LastModified(); !lastModified.IsZero() {
+			entry.LastMod = lastModified.Format(time.DateOnly)
+		}
+		urls = append(urls, entry)
+	}
+	return urls
+}
+
+// Generate renders the sitemap for the given page.
+// Page 0 is the root document: a plain sitemap when all URLs fit into one file,
+// otherwise a sitemap index pointing to pages 1..n via sitemapURL?page=n.
+func (g *SitemapGenerator) Generate(posts []blog.Post, page int, sitemapURL string) ([]byte, error) {
+	urls := g.URLs(posts)
+	pages := (len(urls) + g.maxURLs - 1) / g.maxURLs
+
+	switch {
+	case page == 0 && pages <= 1:
+		return marshalXML(urlSet{Xmlns: sitemapNamespace, URLs: urls})
+	case page == 0:
+		return marshalXML(g.index(urls, pages, sitemapURL))
+	case page < 0 || page > pages:
+		return nil, fmt.Errorf("%w: %d", ErrSitemapPageNotFound, page)
+	}
+
+	start := (page - 1) * g.maxURLs
+	end := min(start+g.maxURLs, len(urls))
+	return marshalXML(urlSet{Xmlns: sitemapNamespace, URLs: urls[start:end]})
+}
+
+// index builds a sitemap index referencing every sitemap page
+func (g *SitemapGenerator) index(urls []URL, pages int, sitemapURL string) sitemapIndex {
+	index := sitemapIndex{Xmlns: sitemapNamespace}
+	for page := 1; page <= pages; page++ {
+		start := (page - 1) * g.maxURLs
+		end := min(start+g.maxURLs, len(urls))
+
+		index.Sitemaps = append(index.Sitemaps, sitemapRecord{
+			Loc:     fmt.Sprintf("%s?page=%d", sitemapURL, page),
+			LastMod: latestLastMod(urls[start:end]),
+		})
+	}
+	return index
+}
+
+// latestLastMod returns the most recent lastmod value among the given URLs
+func latestLastMod(urls []URL) string {
+	latest := ""
+	for _, entry := range urls {
+		// Dates are formatted as YYYY-MM-DD, so they compare lexicographically
+		if entry.LastMod > latest {
+			latest = entry.LastMod
+		}
+	}
+	return latest
+}
+
+// marshalXML renders a document with the XML declaration
+func marshalXML(document any) ([]byte, error) {
+	body, err := xml.MarshalIndent(document, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("error marshalling sitemap: %w", err)
+	}
+	return append([]byte(xml.Header), body...), nil
+}
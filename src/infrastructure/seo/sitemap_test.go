@@ -0,0 +1,77 @@
+package seo
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"buyallmemes.com/blog-api/src/domain/blog"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewSitemapGenerator_InvalidSiteURL(t *testing.T) {
+	generator, err := NewSitemapGenerator("buyallmemes.com", "/posts/")
+
+	assert.ErrorIs(t, err, ErrInvalidSitemapConfig)
+	assert.Nil(t, generator)
+}
+
+func TestSitemapGenerator_URLs(t *testing.T) {
+	generator, err := NewSitemapGenerator("https://buyallmemes.com/", "/posts/")
+	assert.NoError(t, err)
+
+	posts := []blog.Post{
+		{Anchor: "hello-world", Date: "29.03.2024"},
+		{Anchor: "updated-post", Date: "29.03.2024", UpdatedAt: time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{Anchor: "draft-post", Draft: true},
+		{Anchor: "unlisted-post", Unlisted: true},
+		{Anchor: "undated-post"},
+	}
+
+	urls := generator.URLs(posts)
+
+	assert.Equal(t, []URL{
+		{Loc: "https://buyallmemes.com/posts/hello-world", LastMod: "2024-03-29"},
+		{Loc: "https://buyallmemes.com/posts/updated-post", LastMod: "2024-06-01"},
+		{Loc: "https://buyallmemes.com/posts/undated-post"},
+	}, urls)
+}
+
+func TestSitemapGenerator_Generate(t *testing.T) {
+	generator, err := NewSitemapGenerator("https://buyallmemes.com", "/posts/")
+	assert.NoError(t, err)
+
+	body, err := generator.Generate([]blog.Post{{Anchor: "hello-world", Date: "29.03.2024"}}, 0, "https://buyallmemes.com/sitemap.xml")
+
+	assert.NoError(t, err)
+	sitemap := string(body)
+	assert.Contains(t, sitemap, `<?xml version="1.0" encoding="UTF-8"?>`)
+	assert.Contains(t, sitemap, `<urlset xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, sitemap, "<loc>https://buyallmemes.com/posts/hello-world</loc>")
+	assert.Contains(t, sitemap, "<lastmod>2024-03-29</lastmod>")
+}
+
+func TestSitemapGenerator_Generate_SplitsIntoIndex(t *testing.T) {
+	generator, err := NewSitemapGenerator("https://buyallmemes.com", "/posts/")
+	assert.NoError(t, err)
+	generator.maxURLs = 2
+
+	posts := make([]blog.Post, 0, 5)
+	for i := 1; i <= 5; i++ {
+		posts = append(posts, blog.Post{Anchor: fmt.Sprintf("post-%d", i), Date: fmt.Sprintf("0%d.01.2024", i)})
+	}
+
+	index, err := generator.Generate(posts, 0, "https://buyallmemes.com/sitemap.xml")
+	assert.NoError(t, err)
+	assert.Contains(t, string(index), `<sitemapindex xmlns="http://www.sitemaps.org/schemas/sitemap/0.9">`)
+	assert.Contains(t, string(index), "<loc>https://buyallmemes.com/sitemap.xml?page=3</loc>")
+	assert.Contains(t, string(index), "<lastmod>2024-01-04</lastmod>")
+
+	page, err := generator.Generate(posts, 3, "https://buyallmemes.com/sitemap.xml")
+	assert.NoError(t, err)
+	assert.Contains(t, string(page), "<loc>https://buyallmemes.com/posts/post-5</loc>")
+	assert.NotContains(t, string(page), "post-4")
+
+	_, err = generator.Generate(posts, 4, "https://buyallmemes.com/sitemap.xml")
+	assert.ErrorIs(t, err, ErrSitemapPageNotFound)
+}
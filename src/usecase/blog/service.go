@@ -2,6 +2,8 @@ package blog
 
 import (
 	"context"
+	"fmt"
+	"slices"
 	"sort"
 
 	"buyallmemes.com/blog-api/src/domain/blog"
@@ -11,6 +13,12 @@ import (
 type BlogService interface {
 	// GetAllPosts fetches all blog posts
 	GetAllPosts(ctx context.Context) (*blog.Blog, error)
+
+	// GetPost fetches a single blog post by its anchor
+	GetPost(ctx context.Context, anchor string) (*blog.Post, error)
+
+	// GetLoadReport reports the posts that failed to load
+	GetLoadReport(ctx context.Context) (*blog.LoadReport, error)
 }
 
 // blogService implements the BlogService interface
@@ -27,17 +35,96 @@ func NewBlogService(postRepository blog.PostRepository) BlogService {
 
 // GetAllPosts fetches all blog posts and sorts them by filename
 func (s *blogService) GetAllPosts(ctx context.Context) (*blog.Blog, error) {
-	posts, err := s.postRepository.FetchPosts(ctx)
+	posts, report, err := s.fetchPosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &blog.Blog{
+		Posts:  posts,
+		Report: report,
+	}, nil
+}
+
+// GetPost fetches a single blog post by its anchor or one of its aliases,
+// returning blog.ErrPostNotFound when none matches
+func (s *blogService) GetPost(ctx context.Context, anchor string) (*blog.Post, error) {
+	posts, _, err := s.fetchPosts(ctx)
 	if err != nil {
 		return nil, err
 	}
 
+	for i := range posts {
+		if posts[i].HasAnchor(anchor) {
+			return &posts[i], nil
+		}
+	}
+	return nil, fmt.Errorf("%w: %s", blog.ErrPostNotFound, anchor)
+}
+
+// GetLoadReport loads all posts and reports the posts that failed to load
+func (s *blogService) GetLoadReport(ctx context.Context) (*blog.LoadReport, error) {
+	_, report, err := s.fetchPosts(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &report, nil
+}
+
+// fetchPosts fetches all published posts, skips posts with duplicate anchors and sorts them by filename
+func (s *blogService) fetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	posts, report, err := s.postRepository.FetchPosts(ctx)
+	if err != nil {
+		return nil, report, err
+	}
+
 	// Sort posts by filename in descending order (newest first)
 	sort.Slice(posts, func(i, j int) bool {
 		return posts[i].Filename > posts[j].Filename
 	})
 
-	return &blog.Blog{
-		Posts: posts,
-	}, nil
+	posts = removeDuplicateAnchors(posts, &report)
+
+	// Drafts are not served, but still claim their anchors so publishing them does not change which post owns an anchor
+	posts = slices.DeleteFunc(posts, func(post blog.Post) bool { return post.Draft })
+	report.Posts = len(posts)
+	return posts, report, nil
+}
+
+// removeDuplicateAnchors drops posts whose anchor or alias is already claimed by an older post
+// and reports them as blog.ErrDuplicateAnchor
+func removeDuplicateAnchors(posts []blog.Post, report *blog.LoadReport) []blog.Post {
+	owners := make(map[string]string)
+	duplicates := make(map[int]bool)
+
+	// Posts are sorted newest first, the oldest post keeps its anchor
+	for i := len(posts) - 1; i >= 0; i-- {
+		post := posts[i]
+		anchors := append([]string{post.Anchor}, post.Aliases...)
+
+		for _, anchor := range anchors {
+			if owner, ok := owners[anchor]; ok && anchor != "" {
+				err := fmt.Errorf("%w: %q is already used by %s", blog.ErrDuplicateAnchor, anchor, owner)
+				report.AddError(post.Filename, blog.LoadErrorDuplicateAnchor, err)
+				duplicates[i] = true
+				break
+			}
+		}
+		if duplicates[i] {
+			continue
+		}
+		for _, anchor := range anchors {
+			if anchor != "" {
+				owners[anchor] = post.Filename
+			}
+		}
+	}
+
+	unique := make([]blog.Post, 0, len(posts))
+	for i, post := range posts {
+		if !duplicates[i] {
+			unique = append(unique, post)
+		}
+	}
+	return unique
 }
@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"buyallmemes.com/blog-api/src/domain/blog"
 	"github.com/stretchr/testify/assert"
@@ -11,12 +12,13 @@ import (
 
 // StubPostRepository is a stub implementation of the PostRepository interface
 type StubPostRepository struct {
-	posts []blog.Post
-	err   error
+	posts  []blog.Post
+	report blog.LoadReport
+	err    error
 }
 
-func (s *StubPostRepository) FetchPosts(ctx context.Context) ([]blog.Post, error) {
-	return s.posts, s.err
+func (s *StubPostRepository) FetchPosts(ctx context.Context) ([]blog.Post, blog.LoadReport, error) {
+	return s.posts, s.report, s.err
 }
 
 func TestNewBlogService(t *testing.T) {
@@ -87,3 +89,140 @@ func TestBlogService_GetAllPosts_EmptyPosts(t *testing.T) {
 	assert.NotNil(t, result.Posts)
 	assert.Empty(t, result.Posts)
 }
+
+func TestBlogService_GetPost(t *testing.T) {
+	repo := &StubPostRepository{posts: []blog.Post{
+		{Filename: "test1.md", Title: "Test Post 1", Anchor: "test-post-1"},
+		{Filename: "test2.md", Title: "Test Post 2", Anchor: "test-post-2"},
+	}}
+	service := NewBlogService(repo)
+
+	post, err := service.GetPost(context.Background(), "test-post-2")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "test2.md", post.Filename)
+}
+
+func TestBlogService_GetPost_NotFound(t *testing.T) {
+	repo := &StubPostRepository{posts: []blog.Post{
+		{Filename: "test1.md", Title: "Test Post 1", Anchor: "test-post-1"},
+	}}
+	service := NewBlogService(repo)
+
+	post, err := service.GetPost(context.Background(), "missing")
+
+	assert.ErrorIs(t, err, blog.ErrPostNotFound)
+	assert.Nil(t, post)
+}
+
+func TestBlogService_GetPost_Error(t *testing.T) {
+	expectedError := errors.New("repository error")
+	repo := &StubPostRepository{err: expectedError}
+	service := NewBlogService(repo)
+
+	post, err := service.GetPost(context.Background(), "test-post-1")
+
+	assert.Equal(t, expectedError, err)
+	assert.Nil(t, post)
+}
+
+func TestBlogService_GetAllPosts_SkipsDrafts(t *testing.T) {
+	repo := &StubPostRepository{posts: []blog.Post{
+		{Filename: "test1.md", Title: "Test Post 1", Anchor: "test-post-1"},
+		{Filename: "test2.md", Title: "Draft Post", Anchor: "draft-post", Draft: true},
+		{Filename: "test3.md", Title: "Unlisted Post", Anchor: "unlisted-post", Unlisted: true},
+	}}
+	service := NewBlogService(repo)
+
+	result, err := service.GetAllPosts(context.Background())
+
+	assert.NoError(t, err)
+	assert.Len(t, result.Posts, 2)
+	assert.Equal(t, "test3.md", result.Posts[0].Filename)
+	assert.Equal(t, "test1.md", result.Posts[1].Filename)
+	assert.Equal(t, 2, result.Report.Posts)
+}
+
+func TestBlogService_GetPost_Draft(t *testing.T) {
+	repo := &StubPostRepository{posts: []blog.Post{
+		{Filename: "test1.md", Title: "Draft Post", Anchor: "draft-post", Aliases: []string{"old-draft"}, Draft: true},
+	}}
+	service := NewBlogService(repo)
+
+	for _, anchor := range []string{"draft-post", "old-draft"} {
+		post, err := service.GetPost(context.Background(), anchor)
+
+		assert.ErrorIs(t, err, blog.ErrPostNotFound)
+		assert.Nil(t, post)
+	}
+}
+
+func TestBlogService_GetPost_Alias(t *testing.T) {
+	repo := &StubPostRepository{posts: []blog.Post{
+		{Filename: "test1.md", Title: "Renamed Post", Anchor: "renamed-post", Aliases: []string{"test-post-1"}},
+	}}
+	service := NewBlogService(repo)
+
+	post, err := service.GetPost(context.Background(), "test-post-1")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "renamed-post", post.Anchor)
+}
+
+func TestBlogService_GetAllPosts_DuplicateAnchor(t *testing.T) {
+	tests := []struct {
+		name  string
+		posts []blog.Post
+	}{
+		{
+			name: "same anchor",
+			posts: []blog.Post{
+				{Filename: "test1.md", Anchor: "test-post"},
+				{Filename: "test2.md", Anchor: "test-post"},
+			},
+		},
+		{
+			name: "alias of another post",
+			posts: []blog.Post{
+				{Filename: "test1.md", Anchor: "test-post"},
+				{Filename: "test2.md", Anchor: "other-post", Aliases: []string{"test-post"}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service := NewBlogService(&StubPostRepository{posts: tt.posts, report: blog.NewLoadReport(time.Now())})
+
+			result, err := service.GetAllPosts(context.Background())
+
+			// The older post keeps its anchor, the newer one is skipped and reported
+			assert.NoError(t, err)
+			assert.Len(t, result.Posts, 1)
+			assert.Equal(t, "test1.md", result.Posts[0].Filename)
+			assert.Equal(t, 1, result.Report.Posts)
+			assert.Equal(t, []blog.LoadError{{
+				Filename: "test2.md",
+				Kind:     blog.LoadErrorDuplicateAnchor,
+				Message:  `duplicate anchor: "test-post" is already used by test1.md`,
+			}}, result.Report.Errors)
+		})
+	}
+}
+
+func TestBlogService_GetLoadReport(t *testing.T) {
+	report := blog.NewLoadReport(time.Now())
+	report.AddError("broken.md", blog.LoadErrorParse, errors.New("invalid frontmatter"))
+	repo := &StubPostRepository{
+		posts:  []blog.Post{{Filename: "test1.md", Anchor: "test-post-1"}},
+		report: report,
+	}
+	service := NewBlogService(repo)
+
+	result, err := service.GetLoadReport(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, result.Posts)
+	assert.True(t, result.HasErrors())
+	assert.Equal(t, "broken.md", result.Errors[0].Filename)
+}